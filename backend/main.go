@@ -1,9 +1,14 @@
 package main
 
 import (
+	"backend/src/app"
 	"backend/src/config"
 	"backend/src/database"
-	"backend/src/router"
+	"backend/src/logging"
+	"backend/src/metrics"
+	"backend/src/storage"
+	"backend/src/tracing"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,41 +16,151 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(configCommand(os.Args[2:]))
+	}
+
 	fmt.Println("Starting the backend server...")
 	fmt.Println("Loading configuration...")
-	config.Load()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	logging.Setup(cfg.Logging.Format, cfg.Logging.Level)
+
+	shutdownTracing, err := tracing.Setup(
+		context.Background(),
+		cfg.Tracing.Exporter,
+		cfg.Tracing.ServiceName,
+		cfg.Tracing.SampleRatio,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
 
 	fmt.Println("Connecting to the database...")
-	if err := database.ConnectDB(); err != nil {
+	db, err := database.Connect(cfg.Database.URL())
+	if err != nil {
 		log.Fatalf("Failed to connect to the database: %v", err)
 	}
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-c
-		fmt.Println("\nShutting down gracefully...")
-		if err := database.CloseDB(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
-		}
-		os.Exit(0)
-	}()
-	
-	db := database.GetDB()
 	migrationPath, _ := filepath.Abs("./migrations")
-	if err := database.RunMigrations(db, migrationPath); err != nil {
+	schemaVersion, err := database.RunMigrations(db, migrationPath)
+	if err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 	fmt.Println("Migrations completed successfully.")
 
+	if err := metrics.RegisterDB(db); err != nil {
+		log.Fatalf("Failed to register database metrics: %v", err)
+	}
+
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize blob storage: %v", err)
+	}
+
+	application := app.New(cfg, db, store, schemaVersion)
+	application.Scheduler.Start()
+
 	fmt.Println("Running the backend server...")
-	router := router.Generate()
+	server := &http.Server{
+		Addr: 			fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: 		application.Handler,
+		ReadTimeout: 	cfg.Server.ReadTimeout,
+		WriteTimeout: 	cfg.Server.WriteTimeout,
+		IdleTimeout: 	cfg.Server.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
 
-	fmt.Printf("Backend server is running on port %d\n", config.APIPort)
+	fmt.Printf("Backend server is running on port %d\n", cfg.Server.Port)
 	fmt.Println("Database connected and ready!")
 
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", config.APIPort), router))
+	select {
+	case err := <-serverErr:
+		log.Fatalf("Server failed: %v", err)
+	case <-ctx.Done():
+		stop()
+	}
+
+	shutdown(application, server, shutdownTracing)
+}
+
+// shutdown stops the API in dependency order: readiness fails first so
+// load balancers stop routing here, then in-flight requests drain, then
+// background jobs stop, and only then the database connection is closed.
+// Draining and stopping jobs each get ShutdownTimeout, so slow clients
+// cannot eat into the time jobs have to roll back.
+func shutdown(application *app.App, server *http.Server, shutdownTracing func(context.Context) error) {
+	fmt.Println("\nShutting down gracefully...")
+	application.Health.BeginDrain()
+	time.Sleep(application.Config.Server.ShutdownDrainDelay)
+
+	timeout := application.Config.Server.ShutdownTimeout
+
+	httpCtx, cancelHTTP := context.WithTimeout(context.Background(), timeout)
+	defer cancelHTTP()
+
+	if err := server.Shutdown(httpCtx); err != nil {
+		log.Printf("Error draining HTTP connections: %v", err)
+	}
+
+	jobsCtx, cancelJobs := context.WithTimeout(context.Background(), timeout)
+	defer cancelJobs()
+
+	// A job still running holds its transaction open, so the pool stays
+	// open for it and is released with the process instead
+	if err := application.Scheduler.Stop(jobsCtx); err != nil {
+		log.Printf("Error stopping background jobs, leaving the database open: %v", err)
+	} else if err := application.DB.Close(); err != nil {
+		log.Printf("Error closing database connection: %v", err)
+	}
+
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), timeout)
+	defer cancelTracing()
+
+	if err := shutdownTracing(tracingCtx); err != nil {
+		log.Printf("Error flushing traces: %v", err)
+	}
+
+	fmt.Println("Server stopped")
+}
+
+// configCommand implements "config print", which shows the effective
+// configuration with credentials redacted, followed by any problem that
+// would stop the server from starting.
+func configCommand(args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: main config print [flags]")
+		return 2
+	}
+
+	cfg, err := config.Resolve(args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if err := cfg.PrintRedacted(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
 }
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_preferences;
+
+DROP TABLE IF EXISTS notifications;
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE notifications (
+    id SERIAL,
+    user_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    entity_id INTEGER,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT notifications_pk PRIMARY KEY (id),
+    CONSTRAINT notifications_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT notifications_actor_fk FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT notifications_type_check CHECK (type IN ('follow', 'like', 'reply', 'repost', 'mention'))
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_user_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+CREATE TABLE notification_preferences (
+    user_id INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    CONSTRAINT notification_preferences_pk PRIMARY KEY (user_id, type),
+    CONSTRAINT notification_preferences_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT notification_preferences_type_check CHECK (type IN ('follow', 'like', 'reply', 'repost', 'mention'))
+);
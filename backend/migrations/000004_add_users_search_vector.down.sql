@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_nickname_prefix;
+
+DROP INDEX IF EXISTS idx_users_search_vector;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
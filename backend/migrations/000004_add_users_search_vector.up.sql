@@ -0,0 +1,9 @@
+ALTER TABLE users
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(nickname, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(username, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_users_search_vector ON users USING GIN (search_vector);
+
+CREATE INDEX idx_users_nickname_prefix ON users (lower(nickname) text_pattern_ops);
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE media (
+    id SERIAL,
+    owner_id INTEGER NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT media_pk PRIMARY KEY (id),
+    CONSTRAINT media_owner_fk FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT media_storage_key_unique UNIQUE (storage_key)
+);
+
+CREATE INDEX idx_media_owner ON media (owner_id);
\ No newline at end of file
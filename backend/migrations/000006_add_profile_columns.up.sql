@@ -0,0 +1,6 @@
+ALTER TABLE users
+ADD COLUMN bio VARCHAR(160) NOT NULL DEFAULT '',
+ADD COLUMN website VARCHAR(100) NOT NULL DEFAULT '',
+ADD COLUMN location VARCHAR(30) NOT NULL DEFAULT '',
+ADD COLUMN avatar_key VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN banner_key VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
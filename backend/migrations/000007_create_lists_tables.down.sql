@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS list_members;
+
+DROP TABLE IF EXISTS lists;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE lists (
+    id SERIAL,
+    owner_id INTEGER NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    description VARCHAR(160) NOT NULL DEFAULT '',
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT lists_pk PRIMARY KEY (id),
+    CONSTRAINT lists_owner_fk FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_lists_owner ON lists (owner_id);
+
+CREATE TABLE list_members (
+    list_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT list_members_pk PRIMARY KEY (list_id, user_id),
+    CONSTRAINT list_members_list_fk FOREIGN KEY (list_id) REFERENCES lists (id) ON DELETE CASCADE,
+    CONSTRAINT list_members_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_list_members_user ON list_members (user_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE users
+ADD COLUMN deactivated_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_deactivated_at ON users (deactivated_at) WHERE deactivated_at IS NOT NULL;
\ No newline at end of file
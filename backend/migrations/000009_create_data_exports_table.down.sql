@@ -0,0 +1,7 @@
+DELETE FROM notifications WHERE type = 'export_ready';
+
+ALTER TABLE notifications
+DROP CONSTRAINT notifications_type_check,
+ADD CONSTRAINT notifications_type_check CHECK (type IN ('follow', 'like', 'reply', 'repost', 'mention'));
+
+DROP TABLE IF EXISTS data_exports;
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE data_exports (
+    id SERIAL,
+    user_id INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    storage_key VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ,
+    downloaded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT data_exports_pk PRIMARY KEY (id),
+    CONSTRAINT data_exports_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT data_exports_status_check CHECK (status IN ('pending', 'processing', 'ready', 'failed', 'expired'))
+);
+
+CREATE INDEX idx_data_exports_user_created ON data_exports (user_id, created_at DESC);
+CREATE INDEX idx_data_exports_queue ON data_exports (id) WHERE status IN ('pending', 'processing');
+CREATE UNIQUE INDEX idx_data_exports_user_in_progress ON data_exports (user_id) WHERE status IN ('pending', 'processing');
+
+ALTER TABLE notifications
+DROP CONSTRAINT notifications_type_check,
+ADD CONSTRAINT notifications_type_check CHECK (type IN ('follow', 'like', 'reply', 'repost', 'mention', 'export_ready'));
\ No newline at end of file
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"backend/src/authentication"
+	"backend/src/config"
+	export "backend/src/controllers/export"
+	health "backend/src/controllers/health"
+	list "backend/src/controllers/list"
+	login "backend/src/controllers/login"
+	media "backend/src/controllers/media"
+	notification "backend/src/controllers/notification"
+	search "backend/src/controllers/search"
+	user "backend/src/controllers/user"
+	"backend/src/database"
+	"backend/src/dataexport"
+	"backend/src/jobs"
+	mediautil "backend/src/media"
+	"backend/src/middlewares"
+	"backend/src/repositories"
+	"backend/src/router"
+	"backend/src/router/routes"
+	"backend/src/services"
+	"backend/src/storage"
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+// App is the application container. It wires one database pool and one
+// blob storage into the repositories, services, controllers and background
+// jobs built on them, and hands the secret key to the signers that need it
+// instead of publishing it in a package variable. Logging, tracing and
+// metrics are the exception: they register with process-wide providers in
+// main, so every App in a process shares them.
+type App struct {
+	Config 		*config.Config
+	DB 			*sql.DB
+	Storage 	storage.BlobStorage
+	Health 		*health.HealthController
+	Scheduler 	*jobs.Scheduler
+	Handler 	http.Handler
+}
+
+// New builds the application on connections opened by the caller, who
+// stays responsible for closing them. schemaVersion is the version
+// database.RunMigrations left the schema at; readiness fails below it.
+func New(cfg *config.Config, db *sql.DB, store storage.BlobStorage, schemaVersion uint) *App {
+	userRepo := repositories.NewPostgreUserRepository(db)
+	exportRepo := repositories.NewPostgreDataExportRepository(db)
+
+	secretKey := []byte(cfg.SecretKey)
+	tokens := authentication.NewTokenSigner(secretKey)
+	mediaURLs := mediautil.NewURLSigner(secretKey, cfg.Storage.MediaURLExpiration)
+
+	healthController := health.NewHealthController()
+	healthController.RegisterCheck("database", db.PingContext)
+	healthController.RegisterCheck("migrations", func(ctx context.Context) error {
+		return database.CheckMigrations(ctx, db, schemaVersion)
+	})
+
+	controllers := routes.Controllers{
+		Users: 			user.NewUserController(services.NewUserService(userRepo, store, mediaURLs, cfg.Accounts.DeletionGracePeriod)),
+		Login: 			login.NewLoginController(services.NewAuthService(userRepo, tokens, cfg.Accounts.DeletionGracePeriod)),
+		Notifications: 	notification.NewNotificationController(repositories.NewPostgreNotificationRepository(db)),
+		Search: 		search.NewSearchController(repositories.NewPostgreSearchRepository(db)),
+		Media: 			media.NewMediaController(repositories.NewPostgreMediaRepository(db), store, mediaURLs),
+		Lists: 			list.NewListController(repositories.NewPostgreListRepository(db)),
+		Exports: 		export.NewExportController(exportRepo, store, dataexport.NewURLSigner(secretKey)),
+		Health: 		healthController,
+	}
+
+	scheduler := jobs.NewScheduler()
+	scheduler.Register(jobs.NewAccountPurgeJob(
+		userRepo,
+		store,
+		cfg.Accounts.DeletionGracePeriod,
+		cfg.Accounts.PurgeInterval,
+	))
+	scheduler.Register(jobs.NewDataExportJob(
+		exportRepo,
+		store,
+		cfg.DataExport.Expiration,
+		cfg.DataExport.Interval,
+	))
+
+	return &App{
+		Config: 	cfg,
+		DB: 		db,
+		Storage: 	store,
+		Health: 	healthController,
+		Scheduler: 	scheduler,
+		Handler: 	router.Generate(
+			controllers,
+			middlewares.NewAuthenticator(tokens, userRepo),
+			cfg.Server.RequestTimeout,
+		),
+	}
+}
\ No newline at end of file
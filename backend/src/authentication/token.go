@@ -1,7 +1,7 @@
 package authentication
 
 import (
-	"backend/src/config"
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,7 +11,21 @@ import (
 	"golang.org/x/crypto/openpgp/errors"
 )
 
-func GenerateToken(userID uint64) (string, error) {
+type contextKey int
+
+const userIDKey contextKey = iota
+
+// TokenSigner issues the JWTs of the API and checks the ones requests
+// carry, all signed with the same secret key.
+type TokenSigner struct {
+	secretKey []byte
+}
+
+func NewTokenSigner(secretKey []byte) *TokenSigner {
+	return &TokenSigner{secretKey: secretKey}
+}
+
+func (s *TokenSigner) GenerateToken(userID uint64) (string, error) {
 	permissions := jwt.MapClaims{}
 	permissions["authorized"] = true
 	permissions["exp"] = time.Now().Add(time.Hour * 24).Unix()
@@ -19,28 +33,14 @@ func GenerateToken(userID uint64) (string, error) {
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, permissions)
 
-	return token.SignedString([]byte(config.SecretKey))
-}
-
-func ValidateToken(r *http.Request) error {
-	tokenString := extractToken(r)
-	
-	token, err := jwt.Parse(tokenString, getVerificationKey)
-	if err != nil {
-		return err
-	}
-
-	if _, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return nil
-	}
-
-	return errors.InvalidArgumentError("invalid token")
+	return token.SignedString(s.secretKey)
 }
 
-func ExtractUserID(r *http.Request) (uint64, error) {
+// ValidateToken returns the user the bearer token of r was issued to
+func (s *TokenSigner) ValidateToken(r *http.Request) (uint64, error) {
 	tokenString := extractToken(r)
 
-	token, err := jwt.Parse(tokenString, getVerificationKey)
+	token, err := jwt.Parse(tokenString, s.getVerificationKey)
 	if err != nil {
 		return 0, err
 	}
@@ -60,6 +60,21 @@ func ExtractUserID(r *http.Request) (uint64, error) {
 	return 0, errors.InvalidArgumentError("invalid token claims")
 }
 
+// WithUserID marks the request behind ctx as made by userID. Only the
+// authentication middleware calls it, after validating the token.
+func WithUserID(ctx context.Context, userID uint64) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// ExtractUserID returns the user the request was authenticated as
+func ExtractUserID(r *http.Request) (uint64, error) {
+	if userID, ok := r.Context().Value(userIDKey).(uint64); ok {
+		return userID, nil
+	}
+
+	return 0, errors.InvalidArgumentError("request is not authenticated")
+}
+
 func extractToken(r *http.Request) string {
 	token := r.Header.Get("Authorization")
 
@@ -70,10 +85,10 @@ func extractToken(r *http.Request) string {
 	return ""
 }
 
-func getVerificationKey(token *jwt.Token) (interface{}, error) {
+func (s *TokenSigner) getVerificationKey(token *jwt.Token) (interface{}, error) {
 	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
 
-	return config.SecretKey, nil
+	return s.secretKey, nil
 }
\ No newline at end of file
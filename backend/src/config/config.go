@@ -2,71 +2,168 @@ package config
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"io"
+	"time"
 
-	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
-var (
-	DBHost 		= ""
-	DBPort 		= 0
-	DBUser 		= ""
-	DBPassword 	= ""
-	DBName 		= ""
-	DBSSLMode 	= ""
-	DBURL 		= ""
-
-	APIPort 	= 0
-	SecretKey 	= []byte{}
+const (
+	EnvironmentDevelopment 	= "development"
+	EnvironmentProduction 	= "production"
 )
 
-func Load() {
-	var err error
+// Config is the effective configuration of the API. It is assembled by
+// Load from defaults, an optional YAML file, environment variables and
+// command line flags, in that order of increasing precedence.
+type Config struct {
+	Environment string 			`yaml:"environment"`
+	SecretKey 	string 			`yaml:"secret_key"`
+	Database 	DatabaseConfig 	`yaml:"database"`
+	Server 		ServerConfig 	`yaml:"server"`
+	Logging 	LoggingConfig 	`yaml:"logging"`
+	Tracing 	TracingConfig 	`yaml:"tracing"`
+	Storage 	StorageConfig 	`yaml:"storage"`
+	Accounts 	AccountsConfig 	`yaml:"accounts"`
+	DataExport 	DataExportConfig `yaml:"data_export"`
+}
 
-	// Try to load environment variables from a .env file but don't fail if it doesn't exist
-	if err = godotenv.Load(); err != nil {
-		log.Println("No .env file found, using default values from system")
-	}
+type DatabaseConfig struct {
+	Host 		string 	`yaml:"host"`
+	Port 		int 	`yaml:"port"`
+	User 		string 	`yaml:"user"`
+	Password 	string 	`yaml:"password"`
+	Name 		string 	`yaml:"name"`
+	SSLMode 	string 	`yaml:"sslmode"`
+}
 
-	DBHost = os.Getenv("DB_HOST")
-	if DBHost == "" {
-		DBHost = "localhost"
-	}
+type ServerConfig struct {
+	Port 				int 			`yaml:"port"`
+	ReadTimeout 		time.Duration 	`yaml:"read_timeout"`
+	WriteTimeout 		time.Duration 	`yaml:"write_timeout"`
+	IdleTimeout 		time.Duration 	`yaml:"idle_timeout"`
+	RequestTimeout 		time.Duration 	`yaml:"request_timeout"`
+	ShutdownTimeout 	time.Duration 	`yaml:"shutdown_timeout"`
+	ShutdownDrainDelay 	time.Duration 	`yaml:"shutdown_drain_delay"`
+}
 
-	DBPort, err = strconv.Atoi(os.Getenv("DB_PORT"))
-	if err != nil {
-		DBPort = 5432
-	}
+type LoggingConfig struct {
+	Format 	string 	`yaml:"format"`
+	Level 	string 	`yaml:"level"`
+}
 
-	DBUser = os.Getenv("DB_USER")
-	if DBUser == "" {
-		DBUser = "postgres"
-	}
+type TracingConfig struct {
+	Exporter 	string 	`yaml:"exporter"`
+	ServiceName string 	`yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
 
-	DBPassword = os.Getenv("DB_PASSWORD")
-	if DBPassword == "" {
-		DBPassword = "password"
-	}
+type StorageConfig struct {
+	Driver 				string 			`yaml:"driver"`
+	LocalPath 			string 			`yaml:"local_path"`
+	S3 					S3Config 		`yaml:"s3"`
+	MediaURLExpiration 	time.Duration 	`yaml:"media_url_expiration"`
+}
+
+type S3Config struct {
+	Endpoint 	string 	`yaml:"endpoint"`
+	Region 		string 	`yaml:"region"`
+	Bucket 		string 	`yaml:"bucket"`
+	AccessKey 	string 	`yaml:"access_key"`
+	SecretKey 	string 	`yaml:"secret_key"`
+}
 
-	DBName = os.Getenv("DB_NAME")
-	if DBName == "" {
-		DBName = "social_network"
+type AccountsConfig struct {
+	DeletionGracePeriod time.Duration 	`yaml:"deletion_grace_period"`
+	PurgeInterval 		time.Duration 	`yaml:"purge_interval"`
+}
+
+type DataExportConfig struct {
+	Expiration 	time.Duration 	`yaml:"expiration"`
+	Interval 	time.Duration 	`yaml:"interval"`
+}
+
+// Default returns the configuration used for anything no source sets.
+// There is deliberately no default database password or secret key.
+func Default() Config {
+	return Config{
+		Environment: EnvironmentDevelopment,
+		Database: DatabaseConfig{
+			Host: 		"localhost",
+			Port: 		5432,
+			User: 		"postgres",
+			Name: 		"social_network",
+			SSLMode: 	"disable",
+		},
+		Server: ServerConfig{
+			Port: 				5000,
+			ReadTimeout: 		15 * time.Second,
+			WriteTimeout: 		60 * time.Second,
+			IdleTimeout: 		120 * time.Second,
+			RequestTimeout: 	15 * time.Second,
+			ShutdownTimeout: 	30 * time.Second,
+			ShutdownDrainDelay: 5 * time.Second,
+		},
+		Logging: LoggingConfig{
+			Format: "json",
+			Level: 	"info",
+		},
+		Tracing: TracingConfig{
+			Exporter: 		"none",
+			ServiceName: 	"social-network-backend",
+			SampleRatio: 	1,
+		},
+		Storage: StorageConfig{
+			Driver: 			"local",
+			LocalPath: 			"./uploads",
+			MediaURLExpiration: 15 * time.Minute,
+		},
+		Accounts: AccountsConfig{
+			DeletionGracePeriod: 30 * 24 * time.Hour,
+			PurgeInterval: 		 time.Hour,
+		},
+		DataExport: DataExportConfig{
+			Expiration: 48 * time.Hour,
+			Interval: 	30 * time.Second,
+		},
 	}
+}
+
+func (d DatabaseConfig) URL() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		d.Host, d.Port, d.User, d.Password, d.Name, d.SSLMode)
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvironmentProduction
+}
+
+// Redacted returns a copy safe to print or log, with every credential
+// replaced by a marker that only tells whether it was set.
+func (c Config) Redacted() Config {
+	c.SecretKey = redact(c.SecretKey)
+	c.Database.Password = redact(c.Database.Password)
+	c.Storage.S3.AccessKey = redact(c.Storage.S3.AccessKey)
+	c.Storage.S3.SecretKey = redact(c.Storage.S3.SecretKey)
+	return c
+}
 
-	DBSSLMode = os.Getenv("DB_SSLMODE")
-	if DBSSLMode == "" {
-		DBSSLMode = "disable"
+// PrintRedacted writes the redacted configuration as YAML, in the same
+// layout the configuration file uses.
+func (c Config) PrintRedacted(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
 	}
 
-	DBURL = fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		DBHost, DBPort, DBUser, DBPassword, DBName, DBSSLMode)
+	return encoder.Close()
+}
 
-	APIPort, err = strconv.Atoi(os.Getenv("API_PORT"))
-	if err != nil {
-		APIPort = 5000
+func redact(value string) string {
+	if value == "" {
+		return ""
 	}
 
-	SecretKey = []byte(os.Getenv("SECRET_KEY"))
+	return "[REDACTED]"
 }
\ No newline at end of file
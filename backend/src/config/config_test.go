@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============ Implementation of Mocks and Stubs =============
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func validConfig() Config {
+	c := Default()
+	c.SecretKey = "test-secret"
+	return c
+}
+
+func productionConfig() Config {
+	c := validConfig()
+	c.Environment = EnvironmentProduction
+	c.SecretKey = strings.Repeat("k", minProductionSecretLength)
+	c.Database.Password = "s3cure-passw0rd"
+	c.Database.SSLMode = "require"
+	return c
+}
+
+// ============ Test Cases =============
+
+func TestResolve_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: 7000
+  request_timeout: 20s
+database:
+  host: file-host
+  name: file-db
+`)
+	t.Setenv("API_PORT", "8000")
+	t.Setenv("DB_HOST", "env-host")
+
+	c, err := Resolve([]string{"-config", path, "-port", "9000"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if c.Server.Port != 9000 {
+		t.Errorf("expected the flag to win with port 9000, got %d", c.Server.Port)
+	}
+	if c.Database.Host != "env-host" {
+		t.Errorf("expected the environment to win with env-host, got %q", c.Database.Host)
+	}
+	if c.Database.Name != "file-db" || c.Server.RequestTimeout != 20*time.Second {
+		t.Errorf("expected file values to override defaults, got %q and %v", c.Database.Name, c.Server.RequestTimeout)
+	}
+	if c.Database.User != "postgres" {
+		t.Errorf("expected the default user, got %q", c.Database.User)
+	}
+}
+
+func TestResolve_ConfigFileFromEnvironment(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "logging:\n  level: debug\n"))
+
+	c, err := Resolve(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if c.Logging.Level != "debug" {
+		t.Errorf("expected log level debug, got %q", c.Logging.Level)
+	}
+}
+
+func TestResolve_DurationUnits(t *testing.T) {
+	t.Setenv("ACCOUNT_DELETION_GRACE_DAYS", "7")
+	t.Setenv("MEDIA_URL_EXPIRATION_MINUTES", "90s")
+
+	c, err := Resolve(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if c.Accounts.DeletionGracePeriod != 7*24*time.Hour {
+		t.Errorf("expected 7 days, got %v", c.Accounts.DeletionGracePeriod)
+	}
+	if c.Storage.MediaURLExpiration != 90*time.Second {
+		t.Errorf("expected 90s, got %v", c.Storage.MediaURLExpiration)
+	}
+}
+
+func TestResolve_RejectsInvalidValues(t *testing.T) {
+	t.Setenv("DB_PORT", "not-a-port")
+
+	if _, err := Resolve(nil); err == nil || !strings.Contains(err.Error(), "DB_PORT") {
+		t.Errorf("expected an error naming DB_PORT, got %v", err)
+	}
+}
+
+func TestResolve_RejectsUnknownFileKeys(t *testing.T) {
+	path := writeConfigFile(t, "server:\n  prot: 8080\n")
+
+	if _, err := Resolve([]string{"-config", path}); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
+
+func TestValidate_RequiresSecretKey(t *testing.T) {
+	c := Default()
+
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "secret key") {
+		t.Errorf("expected a missing secret key error, got %v", err)
+	}
+
+	c = validConfig()
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected the development config to be valid, got %v", err)
+	}
+}
+
+func TestValidate_ProductionRefusesInsecureSettings(t *testing.T) {
+	c := productionConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected the production config to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		name 	string
+		change 	func(c *Config)
+	}{
+		{"short secret key", func(c *Config) { c.SecretKey = "short" }},
+		{"default password", func(c *Config) { c.Database.Password = "password" }},
+		{"empty password", func(c *Config) { c.Database.Password = "" }},
+		{"sslmode disabled", func(c *Config) { c.Database.SSLMode = "disable" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := productionConfig()
+			tt.change(&c)
+			if err := c.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	c := validConfig()
+	c.Logging.Format = "xml"
+	c.Tracing.SampleRatio = 2
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if !strings.Contains(err.Error(), "log format") || !strings.Contains(err.Error(), "sample ratio") {
+		t.Errorf("expected both problems to be reported, got %v", err)
+	}
+}
+
+func TestPrintRedacted_HidesSecrets(t *testing.T) {
+	c := productionConfig()
+	c.Storage.S3.SecretKey = "s3-secret"
+
+	var buffer bytes.Buffer
+	if err := c.PrintRedacted(&buffer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buffer.String()
+	for _, secret := range []string{c.SecretKey, c.Database.Password, c.Storage.S3.SecretKey} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted", secret)
+		}
+	}
+	if !strings.Contains(output, "request_timeout: 15s") {
+		t.Errorf("expected durations to be printed as strings, got:\n%s", output)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting binds a field of Config to its environment variable and flag
+type setting struct {
+	env 	string
+	flag 	string
+	usage 	string
+	value 	flag.Value
+}
+
+// settings lists every field that can be overridden outside the YAML file.
+// Durations keep the unit suffix of their historical variable names, so
+// HTTP_READ_TIMEOUT_SECONDS=15 still means 15 seconds, but also accept Go
+// duration strings such as "1m30s".
+func settings(c *Config) []setting {
+	return []setting{
+		{"APP_ENV", "env", "development or production", (*stringValue)(&c.Environment)},
+		{"SECRET_KEY", "secret-key", "key signing tokens and links", (*stringValue)(&c.SecretKey)},
+
+		{"DB_HOST", "db-host", "database host", (*stringValue)(&c.Database.Host)},
+		{"DB_PORT", "db-port", "database port", (*intValue)(&c.Database.Port)},
+		{"DB_USER", "db-user", "database user", (*stringValue)(&c.Database.User)},
+		{"DB_PASSWORD", "db-password", "database password", (*stringValue)(&c.Database.Password)},
+		{"DB_NAME", "db-name", "database name", (*stringValue)(&c.Database.Name)},
+		{"DB_SSLMODE", "db-sslmode", "database sslmode", (*stringValue)(&c.Database.SSLMode)},
+
+		{"API_PORT", "port", "HTTP port", (*intValue)(&c.Server.Port)},
+		{"HTTP_READ_TIMEOUT_SECONDS", "http-read-timeout", "HTTP read timeout", &durationValue{&c.Server.ReadTimeout, time.Second}},
+		{"HTTP_WRITE_TIMEOUT_SECONDS", "http-write-timeout", "HTTP write timeout", &durationValue{&c.Server.WriteTimeout, time.Second}},
+		{"HTTP_IDLE_TIMEOUT_SECONDS", "http-idle-timeout", "HTTP keep-alive timeout", &durationValue{&c.Server.IdleTimeout, time.Second}},
+		{"REQUEST_TIMEOUT_SECONDS", "request-timeout", "deadline of each request, 0 disables it", &durationValue{&c.Server.RequestTimeout, time.Second}},
+		{"SHUTDOWN_TIMEOUT_SECONDS", "shutdown-timeout", "time allowed to drain on shutdown", &durationValue{&c.Server.ShutdownTimeout, time.Second}},
+		{"SHUTDOWN_DRAIN_DELAY_SECONDS", "shutdown-drain-delay", "time between failing readiness and shutting down", &durationValue{&c.Server.ShutdownDrainDelay, time.Second}},
+
+		{"LOG_FORMAT", "log-format", "json or text", (*stringValue)(&c.Logging.Format)},
+		{"LOG_LEVEL", "log-level", "debug, info, warn or error", (*stringValue)(&c.Logging.Level)},
+
+		{"TRACING_EXPORTER", "tracing-exporter", "none, stdout or otlp", (*stringValue)(&c.Tracing.Exporter)},
+		{"OTEL_SERVICE_NAME", "tracing-service-name", "service name reported in traces", (*stringValue)(&c.Tracing.ServiceName)},
+		{"TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of traces sampled", (*floatValue)(&c.Tracing.SampleRatio)},
+
+		{"STORAGE_DRIVER", "storage-driver", "local or s3", (*stringValue)(&c.Storage.Driver)},
+		{"STORAGE_LOCAL_PATH", "storage-local-path", "directory of the local driver", (*stringValue)(&c.Storage.LocalPath)},
+		{"S3_ENDPOINT", "s3-endpoint", "S3 endpoint", (*stringValue)(&c.Storage.S3.Endpoint)},
+		{"S3_REGION", "s3-region", "S3 region", (*stringValue)(&c.Storage.S3.Region)},
+		{"S3_BUCKET", "s3-bucket", "S3 bucket", (*stringValue)(&c.Storage.S3.Bucket)},
+		{"S3_ACCESS_KEY", "s3-access-key", "S3 access key", (*stringValue)(&c.Storage.S3.AccessKey)},
+		{"S3_SECRET_KEY", "s3-secret-key", "S3 secret key", (*stringValue)(&c.Storage.S3.SecretKey)},
+		{"MEDIA_URL_EXPIRATION_MINUTES", "media-url-expiration", "lifetime of signed media links", &durationValue{&c.Storage.MediaURLExpiration, time.Minute}},
+
+		{"ACCOUNT_DELETION_GRACE_DAYS", "account-deletion-grace", "time to restore a deactivated account", &durationValue{&c.Accounts.DeletionGracePeriod, 24 * time.Hour}},
+		{"ACCOUNT_PURGE_INTERVAL_MINUTES", "account-purge-interval", "interval of the account purge job", &durationValue{&c.Accounts.PurgeInterval, time.Minute}},
+
+		{"DATA_EXPORT_EXPIRATION_HOURS", "data-export-expiration", "lifetime of data export links", &durationValue{&c.DataExport.Expiration, time.Hour}},
+		{"DATA_EXPORT_INTERVAL_SECONDS", "data-export-interval", "interval of the data export job", &durationValue{&c.DataExport.Interval, time.Second}},
+	}
+}
+
+// Load resolves the configuration and refuses to return one that is
+// invalid or, in production, insecure.
+func Load(args []string) (*Config, error) {
+	c, err := Resolve(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Resolve merges every source without validating the result. Later
+// sources win: defaults, the YAML file named by -config or CONFIG_FILE,
+// environment variables (including those of a .env file), then flags.
+func Resolve(args []string) (*Config, error) {
+	c := Default()
+	bindings := settings(&c)
+
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
+	configFile := flags.String("config", "", "path to a YAML configuration file")
+	fromFlags := map[string]string{}
+	for _, binding := range bindings {
+		flags.Var(rawValue{binding.flag, fromFlags}, binding.flag, binding.usage)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument: %q", flags.Arg(0))
+	}
+
+	// godotenv never overrides variables that are already set
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read .env file: %w", err)
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := readFile(&c, path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Empty variables count as unset, as in .env_dev
+	for _, binding := range bindings {
+		if value := os.Getenv(binding.env); value != "" {
+			if err := binding.value.Set(value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", binding.env, err)
+			}
+		}
+	}
+
+	for _, binding := range bindings {
+		if value, ok := fromFlags[binding.flag]; ok {
+			if err := binding.value.Set(value); err != nil {
+				return nil, fmt.Errorf("invalid -%s: %w", binding.flag, err)
+			}
+		}
+	}
+
+	return &c, nil
+}
+
+// readFile decodes the YAML file over c. Unknown keys are rejected so a
+// typo does not silently leave a setting at its default.
+func readFile(c *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// rawValue records a flag so it can be applied after the other sources
+type rawValue struct {
+	name 	string
+	values 	map[string]string
+}
+
+func (r rawValue) String() string {
+	return ""
+}
+
+func (r rawValue) Set(value string) error {
+	r.values[r.name] = value
+	return nil
+}
+
+type stringValue string
+
+func (s *stringValue) String() string {
+	return string(*s)
+}
+
+func (s *stringValue) Set(value string) error {
+	*s = stringValue(value)
+	return nil
+}
+
+type intValue int
+
+func (i *intValue) String() string {
+	return strconv.Itoa(int(*i))
+}
+
+func (i *intValue) Set(value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not an integer", value)
+	}
+
+	*i = intValue(parsed)
+	return nil
+}
+
+type floatValue float64
+
+func (f *floatValue) String() string {
+	return strconv.FormatFloat(float64(*f), 'g', -1, 64)
+}
+
+func (f *floatValue) Set(value string) error {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+
+	*f = floatValue(parsed)
+	return nil
+}
+
+// durationValue reads a bare number in unit or a Go duration string
+type durationValue struct {
+	duration 	*time.Duration
+	unit 		time.Duration
+}
+
+func (d *durationValue) String() string {
+	return d.duration.String()
+}
+
+func (d *durationValue) Set(value string) error {
+	if count, err := strconv.Atoi(value); err == nil {
+		*d.duration = time.Duration(count) * d.unit
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a duration", value)
+	}
+
+	*d.duration = parsed
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// minProductionSecretLength matches the output size of HMAC-SHA256, which
+// signs both tokens and download links
+const minProductionSecretLength = 32
+
+// Validate reports every problem at once so a broken deployment can be
+// fixed in one pass. Production additionally refuses settings that are
+// only acceptable on a developer machine.
+func (c *Config) Validate() error {
+	var problems []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{EnvironmentDevelopment, EnvironmentProduction}, c.Environment),
+		"environment must be %q or %q, got %q", EnvironmentDevelopment, EnvironmentProduction, c.Environment)
+	check(c.SecretKey != "", "secret key must be set")
+
+	check(validPort(c.Database.Port), "database port %d is out of range", c.Database.Port)
+	check(c.Database.Host != "", "database host must be set")
+	check(c.Database.Name != "", "database name must be set")
+	check(validPort(c.Server.Port), "server port %d is out of range", c.Server.Port)
+
+	check(slices.Contains([]string{"json", "text"}, c.Logging.Format),
+		"log format must be json or text, got %q", c.Logging.Format)
+	check(slices.Contains([]string{"debug", "info", "warn", "warning", "error"}, c.Logging.Level),
+		"unknown log level %q", c.Logging.Level)
+
+	check(slices.Contains([]string{"none", "stdout", "otlp"}, c.Tracing.Exporter),
+		"tracing exporter must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"tracing sample ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	switch c.Storage.Driver {
+	case "local":
+		check(c.Storage.LocalPath != "", "storage local path must be set for the local driver")
+	case "s3":
+		check(c.Storage.S3.Bucket != "", "S3 bucket must be set for the s3 driver")
+	default:
+		check(false, "storage driver must be local or s3, got %q", c.Storage.Driver)
+	}
+
+	nonNegative := []struct {
+		name 		string
+		duration 	time.Duration
+	}{
+		{"read timeout", c.Server.ReadTimeout},
+		{"write timeout", c.Server.WriteTimeout},
+		{"idle timeout", c.Server.IdleTimeout},
+		{"request timeout", c.Server.RequestTimeout},
+		{"shutdown timeout", c.Server.ShutdownTimeout},
+		{"shutdown drain delay", c.Server.ShutdownDrainDelay},
+		{"account deletion grace period", c.Accounts.DeletionGracePeriod},
+	}
+	for _, limit := range nonNegative {
+		check(limit.duration >= 0, "%s must not be negative", limit.name)
+	}
+
+	positive := []struct {
+		name 		string
+		duration 	time.Duration
+	}{
+		{"media URL expiration", c.Storage.MediaURLExpiration},
+		{"account purge interval", c.Accounts.PurgeInterval},
+		{"data export expiration", c.DataExport.Expiration},
+		{"data export interval", c.DataExport.Interval},
+	}
+	for _, limit := range positive {
+		check(limit.duration > 0, "%s must be positive", limit.name)
+	}
+
+	if c.IsProduction() {
+		check(len(c.SecretKey) >= minProductionSecretLength,
+			"secret key must be at least %d bytes in production", minProductionSecretLength)
+		check(c.Database.Password != "" && c.Database.Password != "password",
+			"database password must be set to a non-default value in production")
+		check(c.Database.SSLMode != "disable", "database sslmode must not be disable in production")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(problems...))
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
\ No newline at end of file
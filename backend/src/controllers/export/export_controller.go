@@ -0,0 +1,153 @@
+package export
+
+import (
+	"backend/src/authentication"
+	"backend/src/dataexport"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/logging"
+	"backend/src/metrics"
+	"backend/src/model"
+	"backend/src/storage"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ExportController struct {
+	repo 	interfaces.DataExportRepositoryInterface
+	store 	storage.BlobStorage
+	urls 	*dataexport.URLSigner
+}
+
+func NewExportController(
+	repo interfaces.DataExportRepositoryInterface,
+	store storage.BlobStorage,
+	urls *dataexport.URLSigner,
+) *ExportController {
+	return &ExportController{
+		repo: 	repo,
+		store: 	store,
+		urls: 	urls,
+	}
+}
+
+// RequestDataExport queues an export of everything stored about the user.
+// The archive is built in the background; the user gets an export_ready
+// notification once it can be downloaded.
+func (c *ExportController) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authorizeUser(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := c.repo.CreateDataExport(r.Context(), userID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": 	"Data export requested successfully",
+		"export": 	export,
+	}
+
+	metrics.DataExportsRequested.Inc()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetDataExport reports the state of the latest export and, while it can
+// still be downloaded, the link to it.
+func (c *ExportController) GetDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authorizeUser(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := c.repo.GetLatestDataExport(r.Context(), userID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if export.Status == model.DataExportReady && export.DownloadedAt == nil {
+		export.DownloadURL = c.urls.DownloadURL(export.ID, *export.ExpiresAt)
+	}
+
+	response := map[string]interface{}{
+		"message": 	"Data export retrieved successfully",
+		"export": 	export,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// DownloadDataExport serves the archive behind a signed link. The link is
+// the credential, so no token is needed, and it only works once.
+func (c *ExportController) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	exportID, err := strconv.ParseUint(mux.Vars(r)["exportID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidExportID)
+		return
+	}
+
+	query := r.URL.Query()
+	if err := c.urls.VerifySignature(exportID, query.Get("expires"), query.Get("signature")); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	key, err := c.repo.ClaimDataExportDownload(r.Context(), exportID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	object, err := c.store.Get(key)
+	if err != nil {
+		if err := c.repo.ReleaseDataExportDownload(r.Context(), exportID); err != nil {
+			logging.FromContext(r.Context()).Error("Error releasing data export download", "export_id", exportID, "error", err)
+		}
+		exceptions.HandleError(w, r, fmt.Errorf("reading data export %d: %w", exportID, err))
+		return
+	}
+	defer object.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%d.zip"`, exportID))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, object); err != nil {
+		logging.FromContext(r.Context()).Error("Error streaming data export", "error", err)
+	}
+}
+
+func authorizeUser(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	userID, err := strconv.ParseUint(mux.Vars(r)["userID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
+		return 0, false
+	}
+
+	userIDFromToken, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return 0, false
+	}
+
+	if userID != userIDFromToken {
+		exceptions.HandleError(w, r, exceptions.ErrForbidden)
+		return 0, false
+	}
+
+	return userID, true
+}
\ No newline at end of file
@@ -0,0 +1,253 @@
+package export
+
+import (
+	"backend/src/authentication"
+	"backend/src/dataexport"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/model"
+	"backend/src/storage"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type ExportResponse struct {
+	Message string 				`json:"message"`
+	Export 	model.DataExport 	`json:"export"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockDataExportRepository struct {
+	exports map[uint64]model.DataExport
+	nextId 	uint64
+}
+
+func NewMockDataExportRepository() *MockDataExportRepository {
+	return &MockDataExportRepository{
+		exports: 	make(map[uint64]model.DataExport),
+		nextId: 	1,
+	}
+}
+
+func (m *MockDataExportRepository) CreateDataExport(ctx context.Context, userID uint64) (model.DataExport, error) {
+	for _, export := range m.exports {
+		if export.UserID == userID && (export.Status == model.DataExportPending || export.Status == model.DataExportProcessing) {
+			return model.DataExport{}, exceptions.ErrExportInProgress
+		}
+	}
+
+	export := model.DataExport{
+		ID: 		m.nextId,
+		UserID: 	userID,
+		Status: 	model.DataExportPending,
+		CreatedAt: 	time.Now(),
+	}
+	m.exports[export.ID] = export
+	m.nextId++
+	return export, nil
+}
+
+func (m *MockDataExportRepository) GetLatestDataExport(ctx context.Context, userID uint64) (model.DataExport, error) {
+	var latest model.DataExport
+	for _, export := range m.exports {
+		if export.UserID == userID && export.ID > latest.ID {
+			latest = export
+		}
+	}
+
+	if latest.ID == 0 {
+		return model.DataExport{}, exceptions.ErrExportNotFound
+	}
+	return latest, nil
+}
+
+func (m *MockDataExportRepository) ClaimDataExportDownload(ctx context.Context, exportID uint64) (string, error) {
+	export, exists := m.exports[exportID]
+	if !exists || export.Status != model.DataExportReady || export.DownloadedAt != nil || time.Now().After(*export.ExpiresAt) {
+		return "", exceptions.ErrExportUnavailable
+	}
+
+	now := time.Now()
+	export.DownloadedAt = &now
+	m.exports[exportID] = export
+	return export.StorageKey, nil
+}
+
+func (m *MockDataExportRepository) ReleaseDataExportDownload(ctx context.Context, exportID uint64) error {
+	export := m.exports[exportID]
+	export.DownloadedAt = nil
+	m.exports[exportID] = export
+	return nil
+}
+
+func newTestController(repo interfaces.DataExportRepositoryInterface, store storage.BlobStorage) *ExportController {
+	return NewExportController(repo, store, dataexport.NewURLSigner([]byte("test-secret")))
+}
+
+func authenticatedRequest(method, path string, userID uint64, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(authentication.WithUserID(req.Context(), userID))
+	return mux.SetURLVars(req, vars)
+}
+
+// ============ Test Cases =============
+
+func TestRequestDataExport_OnlyOneInProgress(t *testing.T) {
+	mockRepo := NewMockDataExportRepository()
+	controller := newTestController(mockRepo, nil)
+
+	vars := map[string]string{"userID": "1"}
+
+	rr := httptest.NewRecorder()
+	controller.RequestDataExport(rr, authenticatedRequest("POST", "/users/1/export", 1, vars))
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	var response ExportResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Export.Status != model.DataExportPending {
+		t.Errorf("expected pending export, got %q", response.Export.Status)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.RequestDataExport(rr, authenticatedRequest("POST", "/users/1/export", 1, vars))
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a second request, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestRequestDataExport_OtherUserForbidden(t *testing.T) {
+	mockRepo := NewMockDataExportRepository()
+	controller := newTestController(mockRepo, nil)
+
+	rr := httptest.NewRecorder()
+	controller.RequestDataExport(rr, authenticatedRequest("POST", "/users/1/export", 2, map[string]string{"userID": "1"}))
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	if len(mockRepo.exports) != 0 {
+		t.Errorf("expected no export to be created, got %d", len(mockRepo.exports))
+	}
+}
+
+func TestDownloadDataExport_ServedOnce(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	if err := store.Put("exports/1/archive.zip", strings.NewReader("zip"), 3, "application/zip"); err != nil {
+		t.Fatalf("failed to store archive: %v", err)
+	}
+
+	mockRepo := NewMockDataExportRepository()
+	controller := newTestController(mockRepo, store)
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockRepo.exports[1] = model.DataExport{
+		ID: 			1,
+		UserID: 		1,
+		Status: 		model.DataExportReady,
+		StorageKey: 	"exports/1/archive.zip",
+		ExpiresAt: 		&expiresAt,
+	}
+
+	rr := httptest.NewRecorder()
+	controller.GetDataExport(rr, authenticatedRequest("GET", "/users/1/export", 1, map[string]string{"userID": "1"}))
+
+	var response ExportResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Export.DownloadURL == "" {
+		t.Fatal("expected a download URL for a ready export")
+	}
+
+	download := func(url string) *httptest.ResponseRecorder {
+		req := mux.SetURLVars(httptest.NewRequest("GET", url, nil), map[string]string{"exportID": "1"})
+		rr := httptest.NewRecorder()
+		controller.DownloadDataExport(rr, req)
+		return rr
+	}
+
+	rr = download(response.Export.DownloadURL)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	if rr.Body.String() != "zip" || rr.Header().Get("Content-Type") != "application/zip" {
+		t.Errorf("unexpected archive response: %q (%s)", rr.Body.String(), rr.Header().Get("Content-Type"))
+	}
+
+	rr = download(response.Export.DownloadURL)
+	if rr.Code != http.StatusGone {
+		t.Errorf("expected status %d for a second download, got %d", http.StatusGone, rr.Code)
+	}
+}
+
+func TestDownloadDataExport_InvalidSignature(t *testing.T) {
+	mockRepo := NewMockDataExportRepository()
+	controller := newTestController(mockRepo, nil)
+
+	url := controller.urls.DownloadURL(2, time.Now().Add(time.Hour))
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", url, nil), map[string]string{"exportID": "1"})
+	rr := httptest.NewRecorder()
+	controller.DownloadDataExport(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for a link of another export, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestDownloadDataExport_MissingArchiveKeepsLink(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	mockRepo := NewMockDataExportRepository()
+	controller := newTestController(mockRepo, store)
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockRepo.exports[1] = model.DataExport{
+		ID: 			1,
+		UserID: 		1,
+		Status: 		model.DataExportReady,
+		StorageKey: 	"exports/1/archive.zip",
+		ExpiresAt: 		&expiresAt,
+	}
+
+	url := controller.urls.DownloadURL(1, expiresAt)
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", url, nil), map[string]string{"exportID": "1"})
+	rr := httptest.NewRecorder()
+	controller.DownloadDataExport(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d for a missing archive, got %d", http.StatusInternalServerError, rr.Code)
+	}
+
+	if mockRepo.exports[1].DownloadedAt != nil {
+		t.Error("expected the export to stay downloadable after a failed download")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,129 @@
+package health
+
+import (
+	"backend/src/logging"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency the API needs to serve traffic is
+// usable. It must return once ctx is done.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name 	string
+	check 	Check
+}
+
+type checkResult struct {
+	Status 		string 	`json:"status"`
+	LatencyMs 	int64 	`json:"latency_ms"`
+	Error 		string 	`json:"error,omitempty"`
+}
+
+// HealthController answers the liveness and readiness probes. Readiness
+// runs every registered check, so a dependency the API cannot work without
+// only needs a RegisterCheck call where it is built.
+type HealthController struct {
+	draining 	atomic.Bool
+
+	checksMutex sync.RWMutex
+	checks 		[]namedCheck
+}
+
+func NewHealthController() *HealthController {
+	return &HealthController{}
+}
+
+func (c *HealthController) RegisterCheck(name string, check Check) {
+	c.checksMutex.Lock()
+	defer c.checksMutex.Unlock()
+	c.checks = append(c.checks, namedCheck{name, check})
+}
+
+// BeginDrain makes readiness fail from now on so load balancers stop
+// sending traffic while in-flight requests finish.
+func (c *HealthController) BeginDrain() {
+	c.draining.Store(true)
+}
+
+// Live answers as long as the process can serve HTTP; it never looks at
+// dependencies so an outage of the database does not restart the API.
+func (c *HealthController) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Ready runs every registered check in parallel and answers 503 with the
+// per-dependency breakdown when any of them fails or the server drains.
+func (c *HealthController) Ready(w http.ResponseWriter, r *http.Request) {
+	if c.draining.Load() {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"status": "draining"})
+		return
+	}
+
+	c.checksMutex.RLock()
+	registered := append([]namedCheck(nil), c.checks...)
+	c.checksMutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	results := make(map[string]checkResult, len(registered))
+	var resultsMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, registeredCheck := range registered {
+		wg.Add(1)
+		go func(named namedCheck) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := named.check(ctx)
+			result := checkResult{
+				Status: 	"ok",
+				LatencyMs: 	time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				// The probe is unauthenticated, so the cause stays in the logs
+				logging.FromContext(r.Context()).Error("Readiness check failed", "check", named.name, "error", err)
+				result.Status = "error"
+				result.Error = "unavailable"
+			}
+
+			resultsMutex.Lock()
+			results[named.name] = result
+			resultsMutex.Unlock()
+		}(registeredCheck)
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	for _, result := range results {
+		if result.Status != "ok" {
+			status, code = "unavailable", http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	response := map[string]interface{}{
+		"status": 	status,
+		"checks": 	results,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(response)
+}
\ No newline at end of file
@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type ReadyResponse struct {
+	Status 	string 					`json:"status"`
+	Checks 	map[string]checkResult 	`json:"checks"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+func newTestController(checks ...namedCheck) *HealthController {
+	controller := NewHealthController()
+	for _, named := range checks {
+		controller.RegisterCheck(named.name, named.check)
+	}
+	return controller
+}
+
+func passing(ctx context.Context) error {
+	return nil
+}
+
+// ============ Test Cases =============
+
+func TestLive(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewHealthController().Live(rr, httptest.NewRequest("GET", "/health/live", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestReady_AllChecksPass(t *testing.T) {
+	controller := newTestController(namedCheck{"database", passing})
+	controller.RegisterCheck("cache", passing)
+
+	rr := httptest.NewRecorder()
+	controller.Ready(rr, httptest.NewRequest("GET", "/health/ready", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response ReadyResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Status != "ok" || len(response.Checks) != 2 {
+		t.Errorf("unexpected readiness report: %+v", response)
+	}
+}
+
+func TestReady_FailingCheck(t *testing.T) {
+	controller := newTestController(
+		namedCheck{"database", passing},
+		namedCheck{"migrations", func(ctx context.Context) error {
+			return errors.New("migration 9 is dirty")
+		}},
+	)
+
+	rr := httptest.NewRecorder()
+	controller.Ready(rr, httptest.NewRequest("GET", "/health/ready", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	var response ReadyResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Checks["database"].Status != "ok" {
+		t.Errorf("expected database to be ok, got %+v", response.Checks["database"])
+	}
+
+	if response.Checks["migrations"].Status != "error" || response.Checks["migrations"].Error != "unavailable" {
+		t.Errorf("expected migrations to be unavailable, got %+v", response.Checks["migrations"])
+	}
+
+	if strings.Contains(rr.Body.String(), "migration 9 is dirty") {
+		t.Errorf("expected the check error to stay out of the response, got %s", rr.Body.String())
+	}
+}
+
+func TestReady_FailsWhileDraining(t *testing.T) {
+	controller := newTestController(namedCheck{"database", passing})
+	controller.BeginDrain()
+
+	rr := httptest.NewRecorder()
+	controller.Ready(rr, httptest.NewRequest("GET", "/health/ready", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d while draining, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.Live(rr, httptest.NewRequest("GET", "/health/live", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected liveness to stay %d while draining, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestReady_DrainOnlyAffectsItsController(t *testing.T) {
+	draining := newTestController(namedCheck{"database", passing})
+	serving := newTestController(namedCheck{"database", passing})
+	draining.BeginDrain()
+
+	rr := httptest.NewRecorder()
+	serving.Ready(rr, httptest.NewRequest("GET", "/health/ready", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected another instance to stay ready, got %d", rr.Code)
+	}
+}
\ No newline at end of file
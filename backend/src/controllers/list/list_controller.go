@@ -0,0 +1,270 @@
+package list
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/metrics"
+	"backend/src/model"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ListController struct {
+	repo interfaces.ListRepositoryInterface
+}
+
+func NewListController(repo interfaces.ListRepositoryInterface) *ListController {
+	return &ListController{repo: repo}
+}
+
+func (c *ListController) CreateList(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	list, ok := readList(w, r)
+	if !ok {
+		return
+	}
+	list.OwnerID = userID
+
+	list, err = c.repo.CreateList(r.Context(), list)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": 	"List created successfully",
+		"list": 	list,
+	}
+
+	metrics.ListsCreated.Inc()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) GetMyLists(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	lists, err := c.repo.GetListsByOwner(r.Context(), userID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if lists == nil {
+		lists = []model.List{}
+	}
+
+	response := map[string]interface{}{
+		"message": 	"Lists retrieved successfully",
+		"lists": 	lists,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) GetList(w http.ResponseWriter, r *http.Request) {
+	list, ok := c.loadList(w, r, false)
+	if !ok {
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": 	"List retrieved successfully",
+		"list": 	list,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) UpdateList(w http.ResponseWriter, r *http.Request) {
+	list, ok := c.loadList(w, r, true)
+	if !ok {
+		return
+	}
+
+	changes, ok := readList(w, r)
+	if !ok {
+		return
+	}
+
+	updatedList, err := c.repo.UpdateList(r.Context(), list.ID, changes)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+	updatedList.MemberCount = list.MemberCount
+
+	response := map[string]interface{}{
+		"message": 	"List updated successfully",
+		"list": 	updatedList,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) DeleteList(w http.ResponseWriter, r *http.Request) {
+	list, ok := c.loadList(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := c.repo.DeleteList(r.Context(), list.ID); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	response := map[string]string{
+		"message": "List deleted successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) GetListMembers(w http.ResponseWriter, r *http.Request) {
+	list, ok := c.loadList(w, r, false)
+	if !ok {
+		return
+	}
+
+	members, err := c.repo.GetListMembers(r.Context(), list.ID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if members == nil {
+		members = []model.User{}
+	}
+
+	response := map[string]interface{}{
+		"message": 	"List members retrieved successfully",
+		"members": 	members,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *ListController) AddListMember(w http.ResponseWriter, r *http.Request) {
+	memberID, err := strconv.ParseUint(mux.Vars(r)["userID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
+		return
+	}
+
+	list, ok := c.loadList(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := c.repo.AddListMember(r.Context(), list.ID, memberID); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ListController) RemoveListMember(w http.ResponseWriter, r *http.Request) {
+	memberID, err := strconv.ParseUint(mux.Vars(r)["userID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
+		return
+	}
+
+	list, ok := c.loadList(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := c.repo.RemoveListMember(r.Context(), list.ID, memberID); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadList resolves the {listID} of the request for the authenticated user.
+// Private lists of other users answer 404 so their existence is not leaked;
+// ownerOnly turns a visible list of someone else into a 403.
+func (c *ListController) loadList(
+	w http.ResponseWriter,
+	r *http.Request,
+	ownerOnly bool,
+) (model.List, bool) {
+	listID, err := strconv.ParseUint(mux.Vars(r)["listID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidListID)
+		return model.List{}, false
+	}
+
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return model.List{}, false
+	}
+
+	list, err := c.repo.GetListByID(r.Context(), listID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return model.List{}, false
+	}
+
+	if !list.VisibleTo(userID) {
+		exceptions.HandleError(w, r, exceptions.ErrListNotFound)
+		return model.List{}, false
+	}
+
+	if ownerOnly && list.OwnerID != userID {
+		exceptions.HandleError(w, r, exceptions.ErrForbidden)
+		return model.List{}, false
+	}
+
+	return list, true
+}
+
+func readList(w http.ResponseWriter, r *http.Request) (model.List, bool) {
+	bodyRequest, err := io.ReadAll(r.Body)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+		return model.List{}, false
+	}
+
+	var list model.List
+	if err := json.Unmarshal(bodyRequest, &list); err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+		return model.List{}, false
+	}
+
+	if err := list.BeforeSave(); err != nil {
+		exceptions.HandleError(w, r, err)
+		return model.List{}, false
+	}
+
+	return list, true
+}
@@ -0,0 +1,301 @@
+package list
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type ListResponse struct {
+	Message string 		`json:"message"`
+	List 	model.List 	`json:"list"`
+}
+
+type MembersResponse struct {
+	Message string 			`json:"message"`
+	Members []model.User 	`json:"members"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockListRepository struct {
+	lists 	map[uint64]model.List
+	members map[uint64][]uint64
+	nextId 	uint64
+}
+
+func NewMockListRepository() *MockListRepository {
+	return &MockListRepository{
+		lists: 		make(map[uint64]model.List),
+		members: 	make(map[uint64][]uint64),
+		nextId: 	1,
+	}
+}
+
+func (m *MockListRepository) CreateList(ctx context.Context, list model.List) (model.List, error) {
+	owned := 0
+	for _, existing := range m.lists {
+		if existing.OwnerID == list.OwnerID {
+			owned++
+		}
+	}
+
+	if owned >= model.MaxListsPerUser {
+		return model.List{}, exceptions.ErrListLimitReached
+	}
+
+	list.ID = m.nextId
+	list.CreatedAt = time.Now()
+	m.lists[list.ID] = list
+	m.nextId++
+	return list, nil
+}
+
+func (m *MockListRepository) GetListsByOwner(ctx context.Context, ownerID uint64) ([]model.List, error) {
+	var lists []model.List
+	for _, list := range m.lists {
+		if list.OwnerID == ownerID {
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+
+func (m *MockListRepository) GetListByID(ctx context.Context, listID uint64) (model.List, error) {
+	list, exists := m.lists[listID]
+	if !exists {
+		return model.List{}, exceptions.ErrListNotFound
+	}
+	list.MemberCount = len(m.members[listID])
+	return list, nil
+}
+
+func (m *MockListRepository) UpdateList(ctx context.Context, listID uint64, list model.List) (model.List, error) {
+	existing, exists := m.lists[listID]
+	if !exists {
+		return model.List{}, exceptions.ErrListNotFound
+	}
+
+	existing.Name = list.Name
+	existing.Description = list.Description
+	existing.Private = list.Private
+	m.lists[listID] = existing
+	return existing, nil
+}
+
+func (m *MockListRepository) DeleteList(ctx context.Context, listID uint64) error {
+	if _, exists := m.lists[listID]; !exists {
+		return exceptions.ErrListNotFound
+	}
+	delete(m.lists, listID)
+	delete(m.members, listID)
+	return nil
+}
+
+func (m *MockListRepository) GetListMembers(ctx context.Context, listID uint64) ([]model.User, error) {
+	var users []model.User
+	for _, userID := range m.members[listID] {
+		users = append(users, model.User{ID: userID, Nickname: fmt.Sprintf("user%d", userID)})
+	}
+	return users, nil
+}
+
+func (m *MockListRepository) AddListMember(ctx context.Context, listID uint64, userID uint64) error {
+	for _, member := range m.members[listID] {
+		if member == userID {
+			return nil
+		}
+	}
+
+	if len(m.members[listID]) >= model.MaxListMembers {
+		return exceptions.ErrListMemberLimitReached
+	}
+
+	m.members[listID] = append(m.members[listID], userID)
+	return nil
+}
+
+func (m *MockListRepository) RemoveListMember(ctx context.Context, listID uint64, userID uint64) error {
+	for i, member := range m.members[listID] {
+		if member == userID {
+			m.members[listID] = append(m.members[listID][:i], m.members[listID][i+1:]...)
+			return nil
+		}
+	}
+	return exceptions.ErrUserNotFound
+}
+
+func newTestController(repo interfaces.ListRepositoryInterface) *ListController {
+	return NewListController(repo)
+}
+
+func authenticatedRequest(t *testing.T, method, path string, body []byte, userID uint64, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(authentication.WithUserID(req.Context(), userID))
+	return mux.SetURLVars(req, vars)
+}
+
+// ============ Test Cases =============
+
+func TestCreateList_Success(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	body := []byte(`{"name": "  Gophers  ", "description": "Go people", "private": true}`)
+	rr := httptest.NewRecorder()
+	controller.CreateList(rr, authenticatedRequest(t, "POST", "/lists", body, 1, nil))
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+	}
+
+	var response ListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.List.Name != "Gophers" || response.List.OwnerID != 1 || !response.List.Private {
+		t.Errorf("unexpected list: %+v", response.List)
+	}
+}
+
+func TestCreateList_ValidationAndLimit(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	rr := httptest.NewRecorder()
+	controller.CreateList(rr, authenticatedRequest(t, "POST", "/lists", []byte(`{"name": " "}`), 1, nil))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for empty name, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	for i := 0; i < model.MaxListsPerUser; i++ {
+		mockRepo.CreateList(context.Background(), model.List{OwnerID: 1, Name: fmt.Sprintf("list %d", i)})
+	}
+
+	rr = httptest.NewRecorder()
+	controller.CreateList(rr, authenticatedRequest(t, "POST", "/lists", []byte(`{"name": "one too many"}`), 1, nil))
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d when the limit is reached, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestGetList_PrivateListIsHidden(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateList(context.Background(), model.List{OwnerID: 1, Name: "secret", Private: true})
+	vars := map[string]string{"listID": "1"}
+
+	rr := httptest.NewRecorder()
+	controller.GetList(rr, authenticatedRequest(t, "GET", "/lists/1", nil, 2, vars))
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for another user, got %d", http.StatusNotFound, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.GetList(rr, authenticatedRequest(t, "GET", "/lists/1", nil, 1, vars))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d for the owner, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestUpdateList_OnlyOwner(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateList(context.Background(), model.List{OwnerID: 1, Name: "public"})
+	vars := map[string]string{"listID": "1"}
+	body := []byte(`{"name": "renamed"}`)
+
+	rr := httptest.NewRecorder()
+	controller.UpdateList(rr, authenticatedRequest(t, "PUT", "/lists/1", body, 2, vars))
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for another user, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.UpdateList(rr, authenticatedRequest(t, "PUT", "/lists/1", body, 1, vars))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	if mockRepo.lists[1].Name != "renamed" {
+		t.Errorf("expected list to be renamed, got %q", mockRepo.lists[1].Name)
+	}
+}
+
+func TestListMembers_AddListAndRemove(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateList(context.Background(), model.List{OwnerID: 1, Name: "friends"})
+	memberVars := map[string]string{"listID": "1", "userID": "5"}
+
+	rr := httptest.NewRecorder()
+	controller.AddListMember(rr, authenticatedRequest(t, "PUT", "/lists/1/members/5", nil, 1, memberVars))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.GetListMembers(rr, authenticatedRequest(t, "GET", "/lists/1/members", nil, 2, map[string]string{"listID": "1"}))
+
+	var response MembersResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Members) != 1 || response.Members[0].ID != 5 {
+		t.Errorf("expected member 5, got %+v", response.Members)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.RemoveListMember(rr, authenticatedRequest(t, "DELETE", "/lists/1/members/5", nil, 1, memberVars))
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+
+	if len(mockRepo.members[1]) != 0 {
+		t.Errorf("expected no members left, got %v", mockRepo.members[1])
+	}
+}
+
+func TestAddListMember_LimitReached(t *testing.T) {
+	mockRepo := NewMockListRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateList(context.Background(), model.List{OwnerID: 1, Name: "crowded"})
+	for i := 0; i < model.MaxListMembers; i++ {
+		mockRepo.AddListMember(context.Background(), 1, uint64(100+i))
+	}
+
+	rr := httptest.NewRecorder()
+	controller.AddListMember(rr, authenticatedRequest(t, "PUT", "/lists/1/members/5", nil, 1, map[string]string{"listID": "1", "userID": "5"}))
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
@@ -1,15 +1,19 @@
 package login
 
 import (
+	"backend/src/authentication"
 	"backend/src/interfaces"
 	"backend/src/model"
 	"backend/src/security"
+	"backend/src/services"
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 // ============ Implementation of Types and Structs =============
@@ -38,7 +42,7 @@ func NewMockLoginRepository() *MockLoginRepository {
 	}
 }
 
-func (m *MockLoginRepository) GetUserByEmail(email string) (model.LoginUser, error) {
+func (m *MockLoginRepository) GetUserByEmail(ctx context.Context, email string) (model.LoginUser, error) {
 	if m.failGet {
 		return model.LoginUser{}, nil
 	}
@@ -51,6 +55,16 @@ func (m *MockLoginRepository) GetUserByEmail(email string) (model.LoginUser, err
 	return user, nil
 }
 
+func (m *MockLoginRepository) ReactivateUser(ctx context.Context, userID uint64) error {
+	for email, user := range m.users {
+		if user.ID == userID {
+			user.DeactivatedAt = nil
+			m.users[email] = user
+		}
+	}
+	return nil
+}
+
 func (m *MockLoginRepository) AddUser(user model.LoginUser) {
 	m.users[user.Email] = user
 }
@@ -59,14 +73,8 @@ func (m *MockLoginRepository) SetFailGet(shouldFail bool) {
 	m.failGet = shouldFail
 }
 
-func setTestRepository(repo interfaces.LoginRepositoryInterface) {
-	loginRepo = repo
-	repoErr = nil
-}
-
-func restoreRepository() {
-	loginRepo = nil
-	repoErr = nil
+func newTestController(repo interfaces.LoginRepositoryInterface) *LoginController {
+	return NewLoginController(services.NewAuthService(repo, authentication.NewTokenSigner([]byte("test-secret")), 30*24*time.Hour))
 }
 
 // ============ Test Cases =============
@@ -87,8 +95,7 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	mockRepo.AddUser(testUser)
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	loginData := LoginData{
 		Email:		"teste@gmail.com",
@@ -105,7 +112,7 @@ func TestLogin_Success(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 	
-	Login(rr, req)
+	controller.Login(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
@@ -130,8 +137,7 @@ func TestLogin_Success(t *testing.T) {
 
 func TestLogin_InvalidJSON(t *testing.T) {
 	mockRepo := NewMockLoginRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	invalidJSON := []byte(`"email": "test@gmail.com", "password":}`)
 
@@ -139,9 +145,73 @@ func TestLogin_InvalidJSON(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	Login(rr, req)
+	controller.Login(rr, req)
 
 	if rr.Code != http.StatusBadRequest {
 		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
 	}
+}
+
+func TestLogin_ReactivatesAccountWithinGracePeriod(t *testing.T) {
+	mockRepo := NewMockLoginRepository()
+
+	hashedPassword, err := security.HashPassword("arrozdoce")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	deactivatedAt := time.Now().Add(-24 * time.Hour)
+	mockRepo.AddUser(model.LoginUser{
+		ID: 			1,
+		Email: 			"teste@gmail.com",
+		Password: 		string(hashedPassword),
+		DeactivatedAt: 	&deactivatedAt,
+	})
+	controller := newTestController(mockRepo)
+
+	loginJSON, _ := json.Marshal(LoginData{Email: "teste@gmail.com", Password: "arrozdoce"})
+	req := httptest.NewRequest("POST", "/login", bytes.NewBuffer(loginJSON))
+	rr := httptest.NewRecorder()
+
+	controller.Login(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	if mockRepo.users["teste@gmail.com"].DeactivatedAt != nil {
+		t.Error("Expected account to be reactivated")
+	}
+}
+
+func TestLogin_RejectsAccountPastGracePeriod(t *testing.T) {
+	mockRepo := NewMockLoginRepository()
+
+	hashedPassword, err := security.HashPassword("arrozdoce")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	deactivatedAt := time.Now().Add(-31 * 24 * time.Hour)
+	mockRepo.AddUser(model.LoginUser{
+		ID: 			1,
+		Email: 			"teste@gmail.com",
+		Password: 		string(hashedPassword),
+		DeactivatedAt: 	&deactivatedAt,
+	})
+	controller := newTestController(mockRepo)
+
+	loginJSON, _ := json.Marshal(LoginData{Email: "teste@gmail.com", Password: "arrozdoce"})
+	req := httptest.NewRequest("POST", "/login", bytes.NewBuffer(loginJSON))
+	rr := httptest.NewRecorder()
+
+	controller.Login(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+
+	if mockRepo.users["teste@gmail.com"].DeactivatedAt == nil {
+		t.Error("Expected account to stay deactivated")
+	}
 }
\ No newline at end of file
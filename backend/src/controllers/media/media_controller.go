@@ -0,0 +1,143 @@
+package media
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/logging"
+	"backend/src/metrics"
+	mediautil "backend/src/media"
+	"backend/src/model"
+	"backend/src/storage"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+)
+
+type MediaController struct {
+	repo 	interfaces.MediaRepositoryInterface
+	store 	storage.BlobStorage
+	urls 	*mediautil.URLSigner
+}
+
+func NewMediaController(
+	repo interfaces.MediaRepositoryInterface,
+	store storage.BlobStorage,
+	urls *mediautil.URLSigner,
+) *MediaController {
+	return &MediaController{
+		repo: 	repo,
+		store: 	store,
+		urls: 	urls,
+	}
+}
+
+func (c *MediaController) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	processed, err := mediautil.ReadUpload(w, r, "file")
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	name, err := randomName()
+	if err != nil {
+		exceptions.HandleError(w, r, fmt.Errorf("generating media key: %w", err))
+		return
+	}
+
+	storageKey := fmt.Sprintf("images/%d/%s%s", userID, name, processed.Extension)
+	thumbnailKey := fmt.Sprintf("images/%d/%s_thumb%s", userID, name, processed.Extension)
+
+	if err := c.store.Put(storageKey, bytes.NewReader(processed.Data), int64(len(processed.Data)), processed.ContentType); err != nil {
+		exceptions.HandleError(w, r, fmt.Errorf("storing media: %w", err))
+		return
+	}
+
+	if err := c.store.Put(thumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), processed.ContentType); err != nil {
+		c.store.Delete(storageKey)
+		exceptions.HandleError(w, r, fmt.Errorf("storing media thumbnail: %w", err))
+		return
+	}
+
+	created, err := c.repo.CreateMedia(r.Context(), model.Media{
+		OwnerID: 		userID,
+		StorageKey: 	storageKey,
+		ThumbnailKey: 	thumbnailKey,
+		ContentType: 	processed.ContentType,
+		Width: 			processed.Width,
+		Height: 		processed.Height,
+		Size: 			int64(len(processed.Data)),
+	})
+	if err != nil {
+		c.store.Delete(storageKey)
+		c.store.Delete(thumbnailKey)
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	created.URL = c.urls.SignedURL(created.StorageKey)
+	created.ThumbnailURL = c.urls.SignedURL(created.ThumbnailKey)
+
+	response := map[string]interface{}{
+		"message": 	"Media uploaded successfully",
+		"media": 	created,
+	}
+
+	metrics.MediaUploaded.Inc()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *MediaController) ServeMedia(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+	query := r.URL.Query()
+
+	if err := c.urls.VerifySignature(key, query.Get("expires"), query.Get("signature")); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	object, err := c.store.Get(key)
+	if err != nil {
+		if err == storage.ErrObjectNotFound || err == storage.ErrInvalidKey {
+			exceptions.HandleError(w, r, exceptions.ErrMediaNotFound)
+			return
+		}
+		exceptions.HandleError(w, r, fmt.Errorf("reading media: %w", err))
+		return
+	}
+	defer object.Close()
+
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(key)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, object); err != nil {
+		logging.FromContext(r.Context()).Error("Error streaming media", "error", err)
+	}
+}
+
+func randomName() (string, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
\ No newline at end of file
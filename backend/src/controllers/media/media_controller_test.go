@@ -0,0 +1,167 @@
+package media
+
+import (
+	"backend/src/authentication"
+	"backend/src/interfaces"
+	mediautil "backend/src/media"
+	"backend/src/model"
+	"backend/src/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type ApiResponse struct {
+	Message string 		`json:"message"`
+	Media 	model.Media `json:"media"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockMediaRepository struct {
+	media 	[]model.Media
+	nextId 	uint64
+}
+
+func NewMockMediaRepository() *MockMediaRepository {
+	return &MockMediaRepository{
+		nextId: 1,
+	}
+}
+
+func (m *MockMediaRepository) CreateMedia(ctx context.Context, media model.Media) (model.Media, error) {
+	media.ID = m.nextId
+	media.CreatedAt = time.Now()
+	m.media = append(m.media, media)
+	m.nextId++
+	return media, nil
+}
+
+func newTestController(t *testing.T, repo interfaces.MediaRepositoryInterface) *MediaController {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+
+	return NewMediaController(repo, store, mediautil.NewURLSigner([]byte("test-secret"), time.Minute))
+}
+
+func uploadRequest(fieldName string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(fieldName, "upload.png")
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/media", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req.WithContext(authentication.WithUserID(req.Context(), 7))
+}
+
+func pngImage(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{255, 0, 0, 255})
+	}
+
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+func serve(controller *MediaController, rawURL string) *httptest.ResponseRecorder {
+	parsed, _ := url.Parse(rawURL)
+	req := httptest.NewRequest("GET", rawURL, nil)
+	req = mux.SetURLVars(req, map[string]string{"key": strings.TrimPrefix(parsed.Path, "/media/")})
+	rr := httptest.NewRecorder()
+	controller.ServeMedia(rr, req)
+	return rr
+}
+
+// ============ Test Cases =============
+
+func TestUploadMedia_ThenServeSignedURL(t *testing.T) {
+	mockRepo := NewMockMediaRepository()
+	controller := newTestController(t, mockRepo)
+
+	rr := httptest.NewRecorder()
+	controller.UploadMedia(rr, uploadRequest("file", pngImage(400, 200)))
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	var response ApiResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Media.Width != 400 || response.Media.Height != 200 || response.Media.OwnerID != 7 {
+		t.Errorf("unexpected media: %+v", response.Media)
+	}
+
+	rr = serve(controller, response.Media.ThumbnailURL)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	if rr.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("expected image/png, got %q", rr.Header().Get("Content-Type"))
+	}
+
+	thumbnail, _, err := image.DecodeConfig(rr.Body)
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+
+	if thumbnail.Width != 320 || thumbnail.Height != 160 {
+		t.Errorf("expected 320x160 thumbnail, got %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestUploadMedia_UnsupportedType(t *testing.T) {
+	controller := newTestController(t, NewMockMediaRepository())
+
+	rr := httptest.NewRecorder()
+	controller.UploadMedia(rr, uploadRequest("file", []byte("plain text pretending to be an image")))
+
+	if rr.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status %d, got %d", http.StatusUnsupportedMediaType, rr.Code)
+	}
+}
+
+func TestUploadMedia_MissingFile(t *testing.T) {
+	controller := newTestController(t, NewMockMediaRepository())
+
+	rr := httptest.NewRecorder()
+	controller.UploadMedia(rr, uploadRequest("image", pngImage(10, 10)))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestServeMedia_InvalidSignature(t *testing.T) {
+	controller := newTestController(t, NewMockMediaRepository())
+
+	rr := serve(controller, "/media/images/7/photo.png?expires=9999999999&signature=forged")
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,157 @@
+package notification
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/model"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type NotificationController struct {
+	repo interfaces.NotificationRepositoryInterface
+}
+
+func NewNotificationController(repo interfaces.NotificationRepositoryInterface) *NotificationController {
+	return &NotificationController{repo: repo}
+}
+
+type markAsReadRequest struct {
+	IDs []uint64 `json:"ids"`
+}
+
+func (c *NotificationController) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	unreadOnly := false
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		unreadOnly, err = strconv.ParseBool(unread)
+		if err != nil {
+			exceptions.HandleError(w, r, model.ValidationError {
+				Field:   "unread",
+				Message: "unread must be a boolean",
+				Code:    model.ErrCodeInvalidFormat,
+			})
+			return
+		}
+	}
+
+	notifications, err := c.repo.GetNotifications(r.Context(), userID, unreadOnly)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if notifications == nil {
+		notifications = []model.Notification{}
+	}
+
+	response := map[string]interface{}{
+		"message": 			"Notifications retrieved successfully",
+		"notifications": 	notifications,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *NotificationController) MarkNotificationsAsRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	bodyRequest, err := io.ReadAll(r.Body)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+		return
+	}
+
+	// An empty body marks every unread notification as read
+	var request markAsReadRequest
+	if len(bodyRequest) > 0 {
+		if err := json.Unmarshal(bodyRequest, &request); err != nil {
+			exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+			return
+		}
+	}
+
+	updated, err := c.repo.MarkNotificationsAsRead(r.Context(), userID, request.IDs)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Notifications marked as read",
+		"updated": updated,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *NotificationController) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	preferences, err := c.repo.GetNotificationPreferences(r.Context(), userID)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": 		"Notification preferences retrieved successfully",
+		"preferences": 	preferences,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *NotificationController) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return
+	}
+
+	bodyRequest, err := io.ReadAll(r.Body)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+		return
+	}
+
+	var preferences model.NotificationPreferences
+	if err := json.Unmarshal(bodyRequest, &preferences); err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
+		return
+	}
+
+	if err := preferences.Validate(); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if err := c.repo.UpdateNotificationPreferences(r.Context(), userID, preferences); err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,265 @@
+package notification
+
+import (
+	"backend/src/authentication"
+	"backend/src/interfaces"
+	"backend/src/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type NotificationsResponse struct {
+	Message 		string 					`json:"message"`
+	Notifications	[]model.Notification	`json:"notifications"`
+}
+
+type PreferencesResponse struct {
+	Message 	string 							`json:"message"`
+	Preferences	model.NotificationPreferences	`json:"preferences"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockNotificationRepository struct {
+	notifications 	[]model.Notification
+	preferences 	map[uint64]model.NotificationPreferences
+	nextId 			uint64
+	failGet 		bool
+}
+
+func NewMockNotificationRepository() *MockNotificationRepository {
+	return &MockNotificationRepository{
+		preferences: 	make(map[uint64]model.NotificationPreferences),
+		nextId: 		1,
+	}
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification model.Notification) (bool, error) {
+	if notification.UserID == notification.ActorID {
+		return false, nil
+	}
+
+	if enabled, ok := m.preferences[notification.UserID][notification.Type]; ok && !enabled {
+		return false, nil
+	}
+
+	notification.ID = m.nextId
+	notification.CreatedAt = time.Now()
+	m.notifications = append(m.notifications, notification)
+	m.nextId++
+	return true, nil
+}
+
+func (m *MockNotificationRepository) GetNotifications(ctx context.Context, userID uint64, unreadOnly bool) ([]model.Notification, error) {
+	if m.failGet {
+		return nil, fmt.Errorf("simulated get error")
+	}
+
+	var notifications []model.Notification
+	for _, notification := range m.notifications {
+		if notification.UserID != userID {
+			continue
+		}
+		if unreadOnly && notification.ReadAt != nil {
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (m *MockNotificationRepository) MarkNotificationsAsRead(ctx context.Context, userID uint64, notificationIDs []uint64) (int64, error) {
+	now := time.Now()
+	var updated int64
+
+	for i, notification := range m.notifications {
+		if notification.UserID != userID || notification.ReadAt != nil {
+			continue
+		}
+
+		selected := len(notificationIDs) == 0
+		for _, id := range notificationIDs {
+			if id == notification.ID {
+				selected = true
+			}
+		}
+
+		if selected {
+			m.notifications[i].ReadAt = &now
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (m *MockNotificationRepository) GetNotificationPreferences(ctx context.Context, userID uint64) (model.NotificationPreferences, error) {
+	preferences := model.NotificationPreferences{}
+	for _, notificationType := range model.NotificationTypes {
+		preferences[notificationType] = true
+	}
+	for notificationType, enabled := range m.preferences[userID] {
+		preferences[notificationType] = enabled
+	}
+	return preferences, nil
+}
+
+func (m *MockNotificationRepository) UpdateNotificationPreferences(ctx context.Context, userID uint64, preferences model.NotificationPreferences) error {
+	if m.preferences[userID] == nil {
+		m.preferences[userID] = model.NotificationPreferences{}
+	}
+	for notificationType, enabled := range preferences {
+		m.preferences[userID][notificationType] = enabled
+	}
+	return nil
+}
+
+func newTestController(repo interfaces.NotificationRepositoryInterface) *NotificationController {
+	return NewNotificationController(repo)
+}
+
+func authenticatedRequest(t *testing.T, method, path string, body []byte, userID uint64) *http.Request {
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(authentication.WithUserID(req.Context(), userID))
+}
+
+// ============ Test Cases =============
+
+func TestGetNotifications_UnreadFilter(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 1, ActorID: 2, Type: model.NotificationTypeFollow})
+	mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 1, ActorID: 3, Type: model.NotificationTypeMention, EntityID: 10})
+	mockRepo.MarkNotificationsAsRead(context.Background(), 1, []uint64{1})
+
+	req := authenticatedRequest(t, "GET", "/notifications?unread=true", nil, 1)
+	rr := httptest.NewRecorder()
+
+	controller.GetNotifications(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response NotificationsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Notifications) != 1 || response.Notifications[0].ID != 2 {
+		t.Errorf("expected only unread notification 2, got %+v", response.Notifications)
+	}
+}
+
+func TestGetNotifications_InvalidUnreadParameter(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	req := authenticatedRequest(t, "GET", "/notifications?unread=maybe", nil, 1)
+	rr := httptest.NewRecorder()
+
+	controller.GetNotifications(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestGetNotifications_Unauthorized(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	req := httptest.NewRequest("GET", "/notifications", nil)
+	rr := httptest.NewRecorder()
+
+	controller.GetNotifications(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestMarkNotificationsAsRead_All(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 1, ActorID: 2, Type: model.NotificationTypeLike})
+	mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 1, ActorID: 2, Type: model.NotificationTypeReply})
+	mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 2, ActorID: 1, Type: model.NotificationTypeRepost})
+
+	req := authenticatedRequest(t, "POST", "/notifications/read", nil, 1)
+	rr := httptest.NewRecorder()
+
+	controller.MarkNotificationsAsRead(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	expected := `"updated":2`
+	if !strings.Contains(rr.Body.String(), expected) {
+		t.Errorf("expected body to contain %q, got %q", expected, rr.Body.String())
+	}
+
+	if mockRepo.notifications[2].ReadAt != nil {
+		t.Error("notifications of other users should not be marked as read")
+	}
+}
+
+func TestUpdateNotificationPreferences_DisablesType(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	body, _ := json.Marshal(model.NotificationPreferences{model.NotificationTypeLike: false})
+	req := authenticatedRequest(t, "PUT", "/notifications/preferences", body, 1)
+	rr := httptest.NewRecorder()
+
+	controller.UpdateNotificationPreferences(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+
+	created, _ := mockRepo.CreateNotification(context.Background(), model.Notification{UserID: 1, ActorID: 2, Type: model.NotificationTypeLike})
+	if created {
+		t.Error("expected disabled notification type to be skipped")
+	}
+
+	req = authenticatedRequest(t, "GET", "/notifications/preferences", nil, 1)
+	rr = httptest.NewRecorder()
+
+	controller.GetNotificationPreferences(rr, req)
+
+	var response PreferencesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Preferences[model.NotificationTypeLike] || !response.Preferences[model.NotificationTypeFollow] {
+		t.Errorf("unexpected preferences: %+v", response.Preferences)
+	}
+}
+
+func TestUpdateNotificationPreferences_UnknownType(t *testing.T) {
+	mockRepo := NewMockNotificationRepository()
+	controller := newTestController(mockRepo)
+
+	req := authenticatedRequest(t, "PUT", "/notifications/preferences", []byte(`{"poke": false}`), 1)
+	rr := httptest.NewRecorder()
+
+	controller.UpdateNotificationPreferences(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
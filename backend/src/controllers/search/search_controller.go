@@ -0,0 +1,113 @@
+package search
+
+import (
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/model"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSearchLimit 	= 20
+	maxSearchLimit 		= 50
+)
+
+type SearchController struct {
+	repo interfaces.SearchRepositoryInterface
+}
+
+func NewSearchController(repo interfaces.SearchRepositoryInterface) *SearchController {
+	return &SearchController{repo: repo}
+}
+
+func (c *SearchController) Search(w http.ResponseWriter, r *http.Request) {
+	parameters := r.URL.Query()
+
+	query := strings.TrimSpace(parameters.Get("q"))
+	if query == "" {
+		exceptions.HandleError(w, r, model.ValidationError {
+			Field:   "q",
+			Message: "Search query is required",
+			Code:    model.ErrCodeRequired,
+		})
+		return
+	}
+
+	if len(query) > 100 {
+		exceptions.HandleError(w, r, model.ValidationError {
+			Field:   "q",
+			Message: "Search query must be at most 100 characters long",
+			Code:    model.ErrCodeTooLong,
+		})
+		return
+	}
+
+	searchType := parameters.Get("type")
+	if searchType == "" {
+		searchType = model.SearchTypeUsers
+	}
+
+	switch searchType {
+	case model.SearchTypeUsers:
+	case model.SearchTypePosts:
+		exceptions.HandleError(w, r, exceptions.ErrPostSearchUnavailable)
+		return
+	default:
+		exceptions.HandleError(w, r, model.ValidationError {
+			Field:   "type",
+			Message: "Search type must be users or posts",
+			Code:    model.ErrCodeInvalidFormat,
+		})
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value := parameters.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxSearchLimit {
+			exceptions.HandleError(w, r, model.ValidationError {
+				Field:   "limit",
+				Message: "Limit must be a number between 1 and 50",
+				Code:    model.ErrCodeInvalidFormat,
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	var cursor *model.SearchCursor
+	if value := parameters.Get("cursor"); value != "" {
+		decoded, err := model.DecodeSearchCursor(value)
+		if err != nil {
+			exceptions.HandleError(w, r, err)
+			return
+		}
+		cursor = &decoded
+	}
+
+	users, next, err := c.repo.SearchUsers(r.Context(), query, cursor, limit)
+	if err != nil {
+		exceptions.HandleError(w, r, err)
+		return
+	}
+
+	if users == nil {
+		users = []model.User{}
+	}
+
+	response := map[string]interface{}{
+		"message": 	"Search completed successfully",
+		"users": 	users,
+	}
+
+	if next != nil {
+		response["next_cursor"] = next.Encode()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
\ No newline at end of file
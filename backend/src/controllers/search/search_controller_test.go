@@ -0,0 +1,181 @@
+package search
+
+import (
+	"backend/src/interfaces"
+	"backend/src/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ============ Implementation of Types and Structs =============
+
+type SearchResponse struct {
+	Message 	string 			`json:"message"`
+	Users 		[]model.User 	`json:"users"`
+	NextCursor 	string 			`json:"next_cursor"`
+}
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockSearchRepository struct {
+	users 		[]model.User
+	failSearch 	bool
+}
+
+func NewMockSearchRepository(users ...model.User) *MockSearchRepository {
+	return &MockSearchRepository{
+		users: users,
+	}
+}
+
+// SearchUsers matches nickname prefixes and orders by ID descending, which is
+// enough to exercise the cursor handling of the controller.
+func (m *MockSearchRepository) SearchUsers(ctx context.Context, query string, cursor *model.SearchCursor, limit int) ([]model.User, *model.SearchCursor, error) {
+	if m.failSearch {
+		return nil, nil, fmt.Errorf("simulated search error")
+	}
+
+	var matches []model.User
+	for _, user := range m.users {
+		if !strings.HasPrefix(strings.ToLower(user.Nickname), strings.ToLower(query)) {
+			continue
+		}
+		if cursor != nil && user.ID >= cursor.ID {
+			continue
+		}
+		matches = append(matches, user)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID > matches[j].ID })
+
+	if len(matches) <= limit {
+		return matches, nil, nil
+	}
+
+	matches = matches[:limit]
+	return matches, &model.SearchCursor{Rank: "1", ID: matches[limit-1].ID}, nil
+}
+
+func newTestController(repo interfaces.SearchRepositoryInterface) *SearchController {
+	return NewSearchController(repo)
+}
+
+func searchRequest(controller *SearchController, parameters url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/search?"+parameters.Encode(), nil)
+	rr := httptest.NewRecorder()
+	controller.Search(rr, req)
+	return rr
+}
+
+// ============ Test Cases =============
+
+func TestSearch_UsersWithPagination(t *testing.T) {
+	mockRepo := NewMockSearchRepository(
+		model.User{ID: 1, Username: "john_doe", Nickname: "john"},
+		model.User{ID: 2, Username: "johnny", Nickname: "johnny"},
+		model.User{ID: 3, Username: "jane", Nickname: "jane"},
+	)
+	controller := newTestController(mockRepo)
+
+	rr := searchRequest(controller, url.Values{"q": {"jo"}, "type": {"users"}, "limit": {"1"}})
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response SearchResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Users) != 1 || response.Users[0].ID != 2 {
+		t.Fatalf("expected first page to contain user 2, got %+v", response.Users)
+	}
+
+	if response.NextCursor == "" {
+		t.Fatal("expected a next cursor")
+	}
+
+	rr = searchRequest(controller, url.Values{"q": {"jo"}, "limit": {"1"}, "cursor": {response.NextCursor}})
+
+	response = SearchResponse{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Users) != 1 || response.Users[0].ID != 1 {
+		t.Errorf("expected second page to contain user 1, got %+v", response.Users)
+	}
+
+	if response.NextCursor != "" {
+		t.Errorf("expected no cursor on the last page, got %q", response.NextCursor)
+	}
+}
+
+func TestSearch_MissingQuery(t *testing.T) {
+	controller := newTestController(NewMockSearchRepository())
+
+	rr := searchRequest(controller, url.Values{"q": {"   "}})
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	expected := "Search query is required"
+	if !strings.Contains(rr.Body.String(), expected) {
+		t.Errorf("expected body to contain %q, got %q", expected, rr.Body.String())
+	}
+}
+
+func TestSearch_InvalidParameters(t *testing.T) {
+	controller := newTestController(NewMockSearchRepository())
+
+	tests := []url.Values{
+		{"q": {"john"}, "type": {"groups"}},
+		{"q": {"john"}, "limit": {"0"}},
+		{"q": {"john"}, "limit": {"500"}},
+		{"q": {"john"}, "cursor": {"not-a-cursor"}},
+	}
+
+	// Ranks Go parses as floats but Postgres will not cast to numeric
+	for _, rank := range []string{"NaN", "Inf", "0x1p-2", "1_0"} {
+		cursor := model.SearchCursor{Rank: rank, ID: 1}.Encode()
+		tests = append(tests, url.Values{"q": {"john"}, "cursor": {cursor}})
+	}
+
+	for _, parameters := range tests {
+		rr := searchRequest(controller, parameters)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s, got %d", http.StatusBadRequest, parameters.Encode(), rr.Code)
+		}
+	}
+}
+
+func TestSearch_PostsNotAvailable(t *testing.T) {
+	controller := newTestController(NewMockSearchRepository())
+
+	rr := searchRequest(controller, url.Values{"q": {"hello"}, "type": {"posts"}})
+
+	if rr.Code != http.StatusNotImplemented {
+		t.Errorf("expected status %d, got %d", http.StatusNotImplemented, rr.Code)
+	}
+}
+
+func TestSearch_RepositoryError(t *testing.T) {
+	mockRepo := NewMockSearchRepository()
+	mockRepo.failSearch = true
+	controller := newTestController(mockRepo)
+
+	rr := searchRequest(controller, url.Values{"q": {"john"}})
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
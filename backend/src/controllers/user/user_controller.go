@@ -2,83 +2,45 @@ package controllers
 
 import (
 	"backend/src/authentication"
-	"backend/src/database"
 	"backend/src/exceptions"
-	"backend/src/interfaces"
+	"backend/src/media"
 	"backend/src/model"
-	"backend/src/repositories"
+	"backend/src/services"
 	"encoding/json"
-	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"strconv"
-	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
-var (
-	userRepo 	interfaces.UserRepositoryInterface
-	repoOnce 	sync.Once
-	repoErr 	error
-)
-
-func initRepository() {
-	repoOnce.Do(func() {
-		err := database.ConnectDB()
-		if err != nil {
-			repoErr = err
-			return
-		}
-		userRepo = repositories.NewPostgreUserRepository()
-	})
+// UserController exposes UserService over HTTP: it decodes requests,
+// identifies the caller and encodes what the service returns.
+type UserController struct {
+	service *services.UserService
 }
 
-func GetUserRepository() (interfaces.UserRepositoryInterface, error) {
-	if userRepo != nil {
-		return userRepo, repoErr
-	}
-	
-	initRepository()
-	if repoErr != nil {
-		return nil, repoErr
-	}
-
-	return userRepo, nil
+func NewUserController(service *services.UserService) *UserController {
+	return &UserController{service: service}
 }
 
-func CreateUser(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	repo, err := GetUserRepository()
-	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
-		return
-	}
-
+func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 	bodyRequest, err := io.ReadAll(r.Body)
 	if err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrBadRequest)
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
 		return
 	}
 
 	var user model.User
 	if err := json.Unmarshal(bodyRequest, &user); err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrBadRequest)
-		return
-	}
-
-	if err := user.BeforeCreate("register"); err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, err)
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
 		return
 	}
 
-	user, err = repo.CreateUser(user)
+	user, err = c.service.Register(r.Context(), user)
 	if err != nil {
-		log.Printf("Error creating user: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, err)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
@@ -87,24 +49,15 @@ func CreateUser(w http.ResponseWriter, r *http.Request) {
 		"user":    user,
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
 
-func GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	repo, err := GetUserRepository()
-	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
-		return
-	}
-
-	users, err := repo.GetAllUsers()
+func (c *UserController) GetAllUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := c.service.List(r.Context())
 	if err != nil {
-		log.Printf("Error retrieving users: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
@@ -113,42 +66,22 @@ func GetAllUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(users)
 }
 
-func GetUserByID(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
+func (c *UserController) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	parameters := mux.Vars(r)
 	userID, err := strconv.ParseUint(parameters["userID"], 10, 64)
 	if err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrInvalidUserID)
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
 		return
 	}
 
-	repo, err := GetUserRepository()
+	user, err := c.service.GetByID(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
-		return
-	}
-
-	user, err := repo.GetUserByID(uint64(userID))
-	if err != nil {
-		if err == exceptions.ErrUserNotFound {
-			log.Printf("User with ID %d not found", userID)
-			exceptions.HandleError(w, r, http.StatusNotFound, exceptions.ErrUserNotFound)
-			return
-		}
-
-		log.Printf("Error retrieving user by ID: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
-		return
-	}
-
-	if user == (model.User{}) {
-		exceptions.HandleError(w, r, http.StatusNotFound, nil)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
@@ -157,35 +90,15 @@ func GetUserByID(w http.ResponseWriter, r *http.Request) {
 		"user": 	user,
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
-func GetUserByNickname(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	nickname := mux.Vars(r)["nickname"]
-	if nickname == "" {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrInvalidUserNickname)
-		return
-	}
-
-	repo, err := GetUserRepository()
-	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
-		return
-	}
-
-	user, err := repo.GetUserByNickname(nickname)
+func (c *UserController) GetUserByNickname(w http.ResponseWriter, r *http.Request) {
+	user, err := c.service.GetByNickname(r.Context(), mux.Vars(r)["nickname"])
 	if err != nil {
-		if err == exceptions.ErrUserNotFound {
-			log.Printf("User with nickname %s not found", nickname)
-			exceptions.HandleError(w, r, http.StatusNotFound, exceptions.ErrUserNotFound)
-			return
-		}
-		log.Printf("Error retrieving user by nickname: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
@@ -194,116 +107,140 @@ func GetUserByNickname(w http.ResponseWriter, r *http.Request) {
 		"user": 	user,
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
-func UpdateUserByID(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	parameters := mux.Vars(r)
-	userID, err := strconv.ParseUint(parameters["userID"], 10, 64)
-	if err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrInvalidUserID)
-		return
-	}
-
-	userIDFromToken, err := authentication.ExtractUserID(r)
-	if err != nil {
-		exceptions.HandleError(w, r, http.StatusUnauthorized, exceptions.ErrUnauthorized)
-		return
-	}
-
-	if userID != userIDFromToken {
-		exceptions.HandleError(w, r, http.StatusForbidden, exceptions.ErrForbidden)
+func (c *UserController) UpdateUserByID(w http.ResponseWriter, r *http.Request) {
+	userID, actorID, ok := c.identify(w, r)
+	if !ok {
 		return
 	}
 
-	fmt.Println(userIDFromToken)
-
 	bodyRequest, err := io.ReadAll(r.Body)
 	if err != nil {
-		exceptions.HandleError(w, r, http.StatusUnprocessableEntity, exceptions.ErrBadRequest)
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
 		return
 	}
 
 	var user model.User
 	if err := json.Unmarshal(bodyRequest, &user); err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrBadRequest)
+		exceptions.HandleError(w, r, exceptions.ErrBadRequest)
 		return
 	}
 
-	if err = user.BeforeCreate("update"); err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrBadRequest)
+	if err := c.service.Update(r.Context(), actorID, userID, user); err != nil {
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	repo, err := GetUserRepository()
-	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrDatabaseConnection)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *UserController) DeleteUserByID(w http.ResponseWriter, r *http.Request) {
+	userID, actorID, ok := c.identify(w, r)
+	if !ok {
 		return
 	}
 
-	_, err = repo.UpdateUserByID(userID, user)
+	restoreBefore, err := c.service.Deactivate(r.Context(), actorID, userID)
 	if err != nil {
-		if err == exceptions.ErrUserNotFound {
-			log.Printf("User with ID %d not found", userID)
-			exceptions.HandleError(w, r, http.StatusNotFound, exceptions.ErrUserNotFound)
-			return
-		}
-		log.Printf("Error updating user by ID: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrInternalServer)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-}
+	response := map[string]string{
+		"message": 			"User deleted successfully",
+		"restore_before": 	restoreBefore.UTC().Format(time.RFC3339),
+	}
 
-func DeleteUserByID(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
 
+func (c *UserController) GetUserProfile(w http.ResponseWriter, r *http.Request) {
 	parameters := mux.Vars(r)
 	userID, err := strconv.ParseUint(parameters["userID"], 10, 64)
 	if err != nil {
-		exceptions.HandleError(w, r, http.StatusBadRequest, exceptions.ErrInvalidUserID)
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
 		return
 	}
 
-	userIDFromToken, err := authentication.ExtractUserID(r)
+	profile, err := c.service.GetProfile(r.Context(), userID)
 	if err != nil {
-		exceptions.HandleError(w, r, http.StatusUnauthorized, exceptions.ErrUnauthorized)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	if userID != userIDFromToken {
-		exceptions.HandleError(w, r, http.StatusForbidden, exceptions.ErrForbidden)
+	response := map[string]interface{}{
+		"message": 	"Profile retrieved successfully",
+		"profile": 	profile,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (c *UserController) UploadUserAvatar(w http.ResponseWriter, r *http.Request) {
+	c.uploadProfileImage(w, r, model.ProfileImageAvatar)
+}
+
+func (c *UserController) UploadUserBanner(w http.ResponseWriter, r *http.Request) {
+	c.uploadProfileImage(w, r, model.ProfileImageBanner)
+}
+
+func (c *UserController) uploadProfileImage(w http.ResponseWriter, r *http.Request, kind string) {
+	userID, actorID, ok := c.identify(w, r)
+	if !ok {
+		return
+	}
+
+	// Refuse before reading the upload rather than after
+	if err := c.service.CheckOwner(actorID, userID); err != nil {
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	repo, err := GetUserRepository()
+	processed, err := media.ReadUpload(w, r, "file")
 	if err != nil {
-		log.Printf("Error getting user repository: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrDatabaseConnection)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	err = repo.DeleteUserByID(userID)
+	url, err := c.service.UpdateProfileImage(r.Context(), actorID, userID, kind, processed)
 	if err != nil {
-		if err == exceptions.ErrUserNotFound {
-			log.Printf("User with ID %d not found", userID)
-			exceptions.HandleError(w, r, http.StatusNotFound, exceptions.ErrUserNotFound)
-			return
-		}
-		log.Printf("Error deleting user by ID: %v", err)
-		exceptions.HandleError(w, r, http.StatusInternalServerError, exceptions.ErrDatabaseConnection)
+		exceptions.HandleError(w, r, err)
 		return
 	}
 
-	response := map[string]string{
-		"message": "User deleted successfully",
+	response := map[string]interface{}{
+		"message": 	"Profile " + kind + " updated successfully",
+		"url": 		url,
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
+}
+
+// identify reads the user the request targets and the authenticated user
+// making it. It answers the request itself when either is missing.
+func (c *UserController) identify(w http.ResponseWriter, r *http.Request) (uint64, uint64, bool) {
+	parameters := mux.Vars(r)
+	userID, err := strconv.ParseUint(parameters["userID"], 10, 64)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrInvalidUserID)
+		return 0, 0, false
+	}
+
+	actorID, err := authentication.ExtractUserID(r)
+	if err != nil {
+		exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+		return 0, 0, false
+	}
+
+	return userID, actorID, true
 }
\ No newline at end of file
@@ -1,12 +1,20 @@
 package controllers
 
 import (
+	"backend/src/authentication"
 	"backend/src/exceptions"
 	"backend/src/interfaces"
+	"backend/src/media"
 	"backend/src/model"
+	"backend/src/services"
+	"backend/src/storage"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -14,6 +22,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/lib/pq"
 )
 
 // ============ Implementation of Types and Structs =============
@@ -48,18 +57,28 @@ func NewMockUserRepository() *MockUserRepository {
 	}
 }
 
-func (m *MockUserRepository) CreateUser(user model.User) (model.User, error) {
+func (m *MockUserRepository) CreateUser(ctx context.Context, user model.User) (model.User, error) {
 	if m.failCreate {
 		return model.User{}, fmt.Errorf("simulated create error")
 	}
 
+	for _, existing := range m.users {
+		if existing.Email == user.Email {
+			return model.User{}, &pq.Error{
+				Code: 		"23505",
+				Message: 	`duplicate key value violates unique constraint "users_email_unique"`,
+				Constraint: "users_email_unique",
+			}
+		}
+	}
+
 	user.ID = m.nextId
 	m.users[m.nextId] = user
 	m.nextId++
 	return user, nil
 }
 
-func (m *MockUserRepository) GetAllUsers() ([]model.User, error) {
+func (m *MockUserRepository) GetAllUsers(ctx context.Context) ([]model.User, error) {
 	if m.failGet {
 		return nil, fmt.Errorf("simulated get error")
 	}
@@ -71,7 +90,7 @@ func (m *MockUserRepository) GetAllUsers() ([]model.User, error) {
 	return users, nil
 }
 
-func (m *MockUserRepository) GetUserByID(userID uint64) (model.User, error) {
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID uint64) (model.User, error) {
 	if m.failGet {
 		return model.User{}, fmt.Errorf("simulated get error")
 	}
@@ -83,7 +102,7 @@ func (m *MockUserRepository) GetUserByID(userID uint64) (model.User, error) {
 	return user, nil
 }
 
-func (m *MockUserRepository) GetUserByNickname(nickname string) (model.User, error) {
+func (m *MockUserRepository) GetUserByNickname(ctx context.Context, nickname string) (model.User, error) {
 	if m.failGet {
 		return model.User{}, fmt.Errorf("simulated get error")
 	}
@@ -97,7 +116,7 @@ func (m *MockUserRepository) GetUserByNickname(nickname string) (model.User, err
 	return model.User{}, exceptions.ErrUserNotFound
 }
 
-func (m *MockUserRepository) UpdateUserByID(userID uint64, user model.User) (model.User, error) {
+func (m *MockUserRepository) UpdateUserByID(ctx context.Context, userID uint64, user model.User) (model.User, error) {
 	if m.failGet {
 		return model.User{}, fmt.Errorf("simulated update error")
 	}
@@ -110,12 +129,33 @@ func (m *MockUserRepository) UpdateUserByID(userID uint64, user model.User) (mod
 	existingUser.Username = user.Username
 	existingUser.Nickname = user.Nickname
 	existingUser.Email = user.Email
+	existingUser.Bio = user.Bio
+	existingUser.Website = user.Website
+	existingUser.Location = user.Location
 	m.users[userID] = existingUser
 
 	return existingUser, nil
 }
 
-func (m *MockUserRepository) DeleteUserByID(userID uint64) error {
+func (m *MockUserRepository) UpdateUserImage(ctx context.Context, userID uint64, kind string, key string) (string, error) {
+	existingUser, exists := m.users[userID]
+	if !exists {
+		return "", exceptions.ErrUserNotFound
+	}
+
+	previousKey := existingUser.AvatarKey
+	if kind == model.ProfileImageAvatar {
+		existingUser.AvatarKey = key
+	} else {
+		previousKey = existingUser.BannerKey
+		existingUser.BannerKey = key
+	}
+	m.users[userID] = existingUser
+
+	return previousKey, nil
+}
+
+func (m *MockUserRepository) DeactivateUserByID(ctx context.Context, userID uint64) error {
 	if m.failGet {
 		return fmt.Errorf("simulated delete error")
 	}
@@ -129,20 +169,22 @@ func (m *MockUserRepository) DeleteUserByID(userID uint64) error {
 	return nil
 }
 
-func setTestRepository(repo interfaces.UserRepositoryInterface) {
-	userRepo = repo
+func newTestController(repo interfaces.UserRepositoryInterface) *UserController {
+	urls := media.NewURLSigner([]byte("test-secret"), time.Minute)
+	return NewUserController(services.NewUserService(repo, nil, urls, 30*24*time.Hour))
 }
 
-func restoreRepository() {
-	userRepo = nil
+// authenticate marks req as made by userID, as the authentication
+// middleware does once it has validated the token
+func authenticate(req *http.Request, userID uint64) *http.Request {
+	return req.WithContext(authentication.WithUserID(req.Context(), userID))
 }
 
 // ============ Test Cases =============
 
 func TestCreateUser_Success(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "testuser",
@@ -156,7 +198,7 @@ func TestCreateUser_Success(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	if rr.Code != http.StatusCreated {
 		t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
@@ -180,8 +222,7 @@ func TestCreateUser_Success(t *testing.T) {
 
 func TestCreateUser_ValidationError(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "",
@@ -195,7 +236,7 @@ func TestCreateUser_ValidationError(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	if rr.Code != http.StatusBadRequest {
 		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
@@ -206,20 +247,56 @@ func TestCreateUser_ValidationError(t *testing.T) {
 		t.Errorf("expected body to contain %q, got %q", expectedMsg, rr.Body.String())
 	}
 
-	var response ApiResponse
+	var response exceptions.ProblemResponse
 	err := json.Unmarshal(rr.Body.Bytes(), &response)
 	if err != nil {
 		t.Errorf("failed to unmarshal response: %v", err)
 	}
-	if response.Message == "" {
-		t.Error("response should contain error message")
+	if response.Field != "username" || response.Code != model.ErrCodeRequired {
+		t.Errorf("expected required username problem, got %+v", response)
+	}
+}
+
+func TestCreateUser_DuplicateEmail(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	controller := newTestController(mockRepo)
+
+	user := model.User{
+		Username: "test",
+		Nickname: "Test User",
+		Email: "teste@gmail.com",
+		Password: "password123",
+	}
+	userJSON, _ := json.Marshal(user)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(userJSON))
+		rr := httptest.NewRecorder()
+		controller.CreateUser(rr, req)
+
+		if i == 0 {
+			continue
+		}
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rr.Code, rr.Body.String())
+		}
+
+		var response exceptions.ProblemResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		if response.Code != "EMAIL_TAKEN" {
+			t.Errorf("expected EMAIL_TAKEN, got %q", response.Code)
+		}
+
+		if strings.Contains(rr.Body.String(), "users_email_unique") {
+			t.Error("response should not expose the database error")
+		}
 	}
 }
 
 func TestGetAllUsers(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "TestUser",
@@ -233,12 +310,12 @@ func TestGetAllUsers(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 	
 	req = httptest.NewRequest("GET", "/users", nil)
 	rr = httptest.NewRecorder()
 
-	GetAllUsers(rr, req)
+	controller.GetAllUsers(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
@@ -257,8 +334,7 @@ func TestGetAllUsers(t *testing.T) {
 
 func TestGetUserByID(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "TestUser",
@@ -272,7 +348,7 @@ func TestGetUserByID(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	var apiResponse ApiResponse
 	err := json.Unmarshal(rr.Body.Bytes(), &apiResponse)
@@ -290,7 +366,7 @@ func TestGetUserByID(t *testing.T) {
 
 	rr = httptest.NewRecorder()
 
-	GetUserByID(rr, req)
+	controller.GetUserByID(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", rr.Code)
@@ -310,15 +386,14 @@ func TestGetUserByID(t *testing.T) {
 
 func TestGetUserByID_NotFound(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	userID := "999"
 	req := httptest.NewRequest("GET", "/users/"+userID, nil)
 	req = mux.SetURLVars(req, map[string]string{"userID": userID})
 	rr := httptest.NewRecorder()
 
-	GetUserByID(rr, req)
+	controller.GetUserByID(rr, req)
 
 	if rr.Code != http.StatusNotFound {
 		t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
@@ -331,8 +406,7 @@ func TestGetUserByID_NotFound(t *testing.T) {
 
 func TestGetUserByNickname_Success(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "TestUser",
@@ -346,7 +420,7 @@ func TestGetUserByNickname_Success(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	if rr.Code != http.StatusCreated {
 		t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
@@ -357,7 +431,7 @@ func TestGetUserByNickname_Success(t *testing.T) {
 	req = mux.SetURLVars(req, map[string]string{"nickname": nickname})
 	rr = httptest.NewRecorder()
 
-	GetUserByNickname(rr, req)
+	controller.GetUserByNickname(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", rr.Code)
@@ -382,15 +456,14 @@ func TestGetUserByNickname_Success(t *testing.T) {
 
 func TestGetUserByNickname_NotFound(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	nickname := "nonexistent"
 	req := httptest.NewRequest("GET", "/users/nickname/"+nickname, nil)
 	req = mux.SetURLVars(req, map[string]string{"nickname": nickname})
 	rr := httptest.NewRecorder()
 
-	GetUserByNickname(rr, req)
+	controller.GetUserByNickname(rr, req)
 
 	if rr.Code != http.StatusNotFound {
 		t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
@@ -403,8 +476,7 @@ func TestGetUserByNickname_NotFound(t *testing.T) {
 
 func TestUpdateUserByID_Success(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "test",
@@ -418,7 +490,7 @@ func TestUpdateUserByID_Success(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	var createResponse ApiResponse
 	err := json.Unmarshal(rr.Body.Bytes(), &createResponse)
@@ -444,9 +516,10 @@ func TestUpdateUserByID_Success(t *testing.T) {
 	req = httptest.NewRequest("PUT", "/users/"+userIDStr, bytes.NewBuffer(updatedUserJSON))
 	req.Header.Set("Content-Type", "application/json")
 	req = mux.SetURLVars(req, map[string]string{"userID": userIDStr})
+	req = authenticate(req, userID)
 	rr = httptest.NewRecorder()
 
-	UpdateUserByID(rr, req)
+	controller.UpdateUserByID(rr, req)
 	
 	if rr.Code != http.StatusNoContent {
 		t.Errorf("expected status 200, got %d", rr.Code)
@@ -455,8 +528,7 @@ func TestUpdateUserByID_Success(t *testing.T) {
 
 func TestDeleteUserByID(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	setTestRepository(mockRepo)
-	defer restoreRepository()
+	controller := newTestController(mockRepo)
 
 	user := model.User{
 		Username: "testuser",
@@ -470,7 +542,7 @@ func TestDeleteUserByID(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
-	CreateUser(rr, req)
+	controller.CreateUser(rr, req)
 
 	var createResponse ApiResponse
 	err := json.Unmarshal(rr.Body.Bytes(), &createResponse)
@@ -485,9 +557,10 @@ func TestDeleteUserByID(t *testing.T) {
 	userIDStr := fmt.Sprintf("%d", userID)
 	req = httptest.NewRequest("DELETE", "/users/"+userIDStr, nil)
 	req = mux.SetURLVars(req, map[string]string{"userID": userIDStr})
+	req = authenticate(req, userID)
 	rr = httptest.NewRecorder()
 
-	DeleteUserByID(rr, req)
+	controller.DeleteUserByID(rr, req)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
@@ -497,4 +570,179 @@ func TestDeleteUserByID(t *testing.T) {
 	if !strings.Contains(rr.Body.String(), expected) {
 		t.Errorf("expected body to contain %q, got %q", expected, rr.Body.String())
 	}
+}
+
+func TestUpdateUserByID_InvalidWebsite(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateUser(context.Background(), model.User{Username: "test", Nickname: "Test User", Email: "test@gmail.com"})
+
+	updatedUser := model.User{
+		Username: "test",
+		Nickname: "Test User",
+		Email: "test@gmail.com",
+		Website: "javascript:alert(1)",
+	}
+	updatedUserJSON, _ := json.Marshal(updatedUser)
+
+	req := httptest.NewRequest("PUT", "/users/1", bytes.NewBuffer(updatedUserJSON))
+	req = mux.SetURLVars(req, map[string]string{"userID": "1"})
+	req = authenticate(req, 1)
+	rr := httptest.NewRecorder()
+
+	controller.UpdateUserByID(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestProfileValidation(t *testing.T) {
+	tests := []struct {
+		name 	string
+		user 	model.User
+		field 	string
+	}{
+		{"bio too long", model.User{Bio: strings.Repeat("é", 161)}, "bio"},
+		{"website without scheme", model.User{Website: "example.com"}, "website"},
+		{"website with ftp scheme", model.User{Website: "ftp://example.com"}, "website"},
+		{"location too long", model.User{Location: strings.Repeat("a", 31)}, "location"},
+	}
+
+	for _, test := range tests {
+		test.user.Username = "test"
+		test.user.Nickname = "Test User"
+		test.user.Email = "test@gmail.com"
+
+		err := test.user.BeforeCreate("update")
+		validationErr, ok := err.(model.ValidationError)
+		if !ok || validationErr.Field != test.field {
+			t.Errorf("%s: expected validation error on %q, got %v", test.name, test.field, err)
+		}
+	}
+
+	valid := model.User{
+		Username: "test",
+		Nickname: "Test User",
+		Email: "test@gmail.com",
+		Bio: strings.Repeat("é", 160),
+		Website: " https://example.com/me ",
+		Location: "São Paulo",
+	}
+	if err := valid.BeforeCreate("update"); err != nil {
+		t.Errorf("expected valid profile, got %v", err)
+	}
+
+	if valid.Website != "https://example.com/me" {
+		t.Errorf("expected website to be trimmed, got %q", valid.Website)
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	controller := newTestController(mockRepo)
+
+	mockRepo.CreateUser(context.Background(), model.User{
+		Username: "test",
+		Nickname: "Test User",
+		Email: "test@gmail.com",
+		Bio: "Hello there",
+		AvatarKey: "profiles/1/avatar_abc.png",
+	})
+
+	req := httptest.NewRequest("GET", "/users/1/profile", nil)
+	req = mux.SetURLVars(req, map[string]string{"userID": "1"})
+	rr := httptest.NewRecorder()
+
+	controller.GetUserProfile(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response struct {
+		Profile model.Profile `json:"profile"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Profile.Bio != "Hello there" {
+		t.Errorf("expected bio in profile, got %+v", response.Profile)
+	}
+
+	if !strings.HasPrefix(response.Profile.AvatarURL, "/media/profiles/1/avatar_abc.png?") {
+		t.Errorf("expected signed avatar URL, got %q", response.Profile.AvatarURL)
+	}
+
+	if strings.Contains(rr.Body.String(), "test@gmail.com") {
+		t.Error("public profile should not expose the email")
+	}
+}
+
+func TestUploadUserAvatar(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+	urls := media.NewURLSigner([]byte("test-secret"), time.Minute)
+	controller := NewUserController(services.NewUserService(mockRepo, store, urls, 30*24*time.Hour))
+
+	mockRepo.CreateUser(context.Background(), model.User{Username: "test", Nickname: "Test User", Email: "test@gmail.com"})
+	store.Put("profiles/1/old.png", strings.NewReader("old"), 3, "image/png")
+	mockRepo.UpdateUserImage(context.Background(), 1, model.ProfileImageAvatar, "profiles/1/old.png")
+
+	img := image.NewRGBA(image.Rect(0, 0, 600, 600))
+	var imageData bytes.Buffer
+	png.Encode(&imageData, img)
+
+	uploadRequest := func(userID string) *http.Request {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "avatar.png")
+		part.Write(imageData.Bytes())
+		writer.Close()
+
+		req := httptest.NewRequest("PUT", "/users/"+userID+"/avatar", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req = mux.SetURLVars(req, map[string]string{"userID": userID})
+		return authenticate(req, 1)
+	}
+
+	rr := httptest.NewRecorder()
+	controller.UploadUserAvatar(rr, uploadRequest("2"))
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for another user, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	controller.UploadUserAvatar(rr, uploadRequest("1"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	avatarKey := mockRepo.users[1].AvatarKey
+	if !strings.HasPrefix(avatarKey, "profiles/1/avatar_") {
+		t.Errorf("expected avatar key to be updated, got %q", avatarKey)
+	}
+
+	if _, err := store.Get("profiles/1/old.png"); err != storage.ErrObjectNotFound {
+		t.Errorf("expected previous avatar to be deleted, got %v", err)
+	}
+
+	object, err := store.Get(avatarKey)
+	if err != nil {
+		t.Fatalf("expected avatar to be stored: %v", err)
+	}
+	defer object.Close()
+
+	avatar, _, err := image.DecodeConfig(object)
+	if err != nil || avatar.Width != 320 {
+		t.Errorf("expected 320px avatar, got %+v (%v)", avatar, err)
+	}
 }
\ No newline at end of file
@@ -1,40 +1,26 @@
 package database
 
 import (
-	"backend/src/config"
 	"database/sql"
 	"fmt"
 
 	_ "github.com/lib/pq"
 )
 
-var DB *sql.DB
-
-func ConnectDB() error {
-	var err error
-
-	DB, err = sql.Open("postgres", config.DBURL)
+// Connect opens the connection pool the whole application shares and
+// checks that the database answers.
+func Connect(url string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", url)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if err = DB.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	fmt.Println("Database connection established successfully")
 
-	return nil
-}
-
-func GetDB() *sql.DB {
-	return DB
-}
-
-func CloseDB() error {
-	if DB != nil {
-		return DB.Close()
-	}
-
-	return nil
+	return db, nil
 }
\ No newline at end of file
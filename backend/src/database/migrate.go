@@ -1,7 +1,9 @@
 package database
 
 import (
+    "context"
     "database/sql"
+    "errors"
     "fmt"
     "os"
     "path/filepath"
@@ -11,20 +13,22 @@ import (
     _ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
-func RunMigrations(db *sql.DB, migrationPath string) error {
+// RunMigrations brings the schema up to date and returns the version it
+// ended at, which CheckMigrations later expects.
+func RunMigrations(db *sql.DB, migrationPath string) (uint, error) {
     fmt.Printf("🔍 Checking migration path: %s\n", migrationPath)
     
     // Verificar se o diretório existe
     if _, err := os.Stat(migrationPath); os.IsNotExist(err) {
         fmt.Printf("❌ Migration directory does not exist: %s\n", migrationPath)
-        return fmt.Errorf("migration directory does not exist: %s", migrationPath)
+        return 0, fmt.Errorf("migration directory does not exist: %s", migrationPath)
     }
     
     // Listar arquivos na pasta
     files, err := filepath.Glob(filepath.Join(migrationPath, "*.sql"))
     if err != nil {
         fmt.Printf("❌ Error reading migration files: %v\n", err)
-        return fmt.Errorf("error reading migration files: %w", err)
+        return 0, fmt.Errorf("error reading migration files: %w", err)
     }
     
     fmt.Printf("📁 Found %d migration files:\n", len(files))
@@ -34,13 +38,13 @@ func RunMigrations(db *sql.DB, migrationPath string) error {
     
     if len(files) == 0 {
         fmt.Println("⚠️  No migration files found - skipping migrations")
-        return nil
+        return 0, nil
     }
 
     driver, err := postgres.WithInstance(db, &postgres.Config{})
     if err != nil {
         fmt.Printf("❌ Could not create migrate driver: %v\n", err)
-        return fmt.Errorf("could not create migrate driver: %w", err)
+        return 0, fmt.Errorf("could not create migrate driver: %w", err)
     }
 
     sourceURL := fmt.Sprintf("file://%s", migrationPath)
@@ -53,7 +57,7 @@ func RunMigrations(db *sql.DB, migrationPath string) error {
     )
     if err != nil {
         fmt.Printf("❌ Could not create migrate instance: %v\n", err)
-        return fmt.Errorf("could not create migrate instance: %w", err)
+        return 0, fmt.Errorf("could not create migrate instance: %w", err)
     }
 
     // Verificar versão atual
@@ -67,12 +71,43 @@ func RunMigrations(db *sql.DB, migrationPath string) error {
     fmt.Println("🔄 Running migrations...")
     if err := m.Up(); err != nil && err != migrate.ErrNoChange {
         fmt.Printf("❌ Could not run migrations: %v\n", err)
-        return fmt.Errorf("could not run migrations: %w", err)
+        return 0, fmt.Errorf("could not run migrations: %w", err)
     }
     
     // Verificar nova versão
     newVersion, _, _ := m.Version()
     fmt.Printf("✅ Migration completed successfully! New version: %d\n", newVersion)
 
-    return nil
+    return newVersion, nil
 }
+
+// CheckMigrations reports an error when the schema was left dirty or
+// rolled back below expected, the version RunMigrations returned. A newer
+// schema is fine: during a rolling deploy the new instances migrate while
+// the old ones are still serving.
+func CheckMigrations(ctx context.Context, db *sql.DB, expected uint) error {
+    if expected == 0 {
+        return nil
+    }
+
+    if db == nil {
+        return errors.New("database is not connected")
+    }
+
+    var version uint
+    var dirty bool
+    err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+    if err != nil {
+        return fmt.Errorf("could not read migration version: %w", err)
+    }
+
+    if dirty {
+        return fmt.Errorf("migration %d is dirty", version)
+    }
+
+    if version < expected {
+        return fmt.Errorf("database is at version %d, expected at least %d", version, expected)
+    }
+
+    return nil
+}
\ No newline at end of file
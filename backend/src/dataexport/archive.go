@@ -0,0 +1,109 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"backend/src/model"
+	"backend/src/storage"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+// WriteArchive writes the ZIP a user receives when exporting their data:
+// one JSON document per kind of data plus the files they uploaded.
+// Files that are already gone from storage are left out of the archive.
+func WriteArchive(w io.Writer, data model.UserData, store storage.BlobStorage) error {
+	archive := zip.NewWriter(w)
+
+	files := map[string]string{}
+	if data.User.AvatarKey != "" {
+		files["profile/avatar"+path.Ext(data.User.AvatarKey)] = data.User.AvatarKey
+	}
+	if data.User.BannerKey != "" {
+		files["profile/banner"+path.Ext(data.User.BannerKey)] = data.User.BannerKey
+	}
+
+	// Media URLs point at the copy inside the archive
+	for i, item := range data.Media {
+		name := "media/" + path.Base(item.StorageKey)
+		files[name] = item.StorageKey
+		data.Media[i].URL = name
+	}
+
+	documents := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", data.User},
+		{"lists.json", data.Lists},
+		{"notifications.json", data.Notifications},
+		{"notification_preferences.json", data.NotificationPreferences},
+		{"media.json", data.Media},
+	}
+
+	for _, document := range documents {
+		if err := writeJSON(archive, document.name, document.value); err != nil {
+			return err
+		}
+	}
+
+	for name, key := range files {
+		if err := copyObject(archive, name, key, store); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return nil
+}
+
+func writeJSON(archive *zip.Writer, name string, value interface{}) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name: 		name,
+		Method: 	zip.Deflate,
+		Modified: 	time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func copyObject(archive *zip.Writer, name string, key string, store storage.BlobStorage) error {
+	object, err := store.Get(key)
+	if err == storage.ErrObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	defer object.Close()
+
+	// Images are already compressed, storing them avoids wasted CPU
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name: 		name,
+		Method: 	zip.Store,
+		Modified: 	time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(file, object); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", key, err)
+	}
+
+	return nil
+}
\ No newline at end of file
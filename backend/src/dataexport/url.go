@@ -0,0 +1,57 @@
+package dataexport
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidSignature = errors.New("invalid or expired download link")
+
+// URLSigner signs the links to export archives
+type URLSigner struct {
+	secretKey []byte
+}
+
+func NewURLSigner(secretKey []byte) *URLSigner {
+	return &URLSigner{secretKey: secretKey}
+}
+
+// DownloadURL returns the link to an export archive. It is valid until
+// expiresAt; the repository makes sure it can only be used once.
+func (s *URLSigner) DownloadURL(exportID uint64, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(exportID, expires))
+
+	return "/exports/" + strconv.FormatUint(exportID, 10) + "?" + query.Encode()
+}
+
+func (s *URLSigner) VerifySignature(exportID uint64, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(s.sign(exportID, expiresAt)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *URLSigner) sign(exportID uint64, expires int64) string {
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write([]byte("export\n" + strconv.FormatUint(exportID, 10) + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
\ No newline at end of file
@@ -0,0 +1,123 @@
+package exceptions
+
+import (
+	"backend/src/dataexport"
+	"backend/src/media"
+	"backend/src/model"
+	"errors"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+// Problem is what the catalogue knows about an error: the HTTP status it
+// is answered with and a machine code clients can rely on across
+// releases, unlike the human readable message.
+type Problem struct {
+	Status 	int
+	Code 	string
+}
+
+// ProblemCodeInternal is answered for every error missing from the
+// catalogue, whose message is never shown
+const ProblemCodeInternal = "INTERNAL_ERROR"
+
+var catalogue = map[error]Problem{
+	ErrBadRequest: 				{http.StatusBadRequest, "MALFORMED_REQUEST"},
+	ErrInvalidUserID: 			{http.StatusBadRequest, "INVALID_USER_ID"},
+	ErrInvalidUserNickname: 	{http.StatusBadRequest, "INVALID_NICKNAME"},
+	ErrInvalidListID: 			{http.StatusBadRequest, "INVALID_LIST_ID"},
+	ErrInvalidExportID: 		{http.StatusBadRequest, "INVALID_EXPORT_ID"},
+	ErrInvalidCredentials: 		{http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+	ErrUnauthorized: 			{http.StatusUnauthorized, "UNAUTHORIZED"},
+	ErrAccountDeactivated: 		{http.StatusUnauthorized, "ACCOUNT_DEACTIVATED"},
+	ErrForbidden: 				{http.StatusForbidden, "FORBIDDEN"},
+	ErrUserNotFound: 			{http.StatusNotFound, "USER_NOT_FOUND"},
+	ErrListNotFound: 			{http.StatusNotFound, "LIST_NOT_FOUND"},
+	ErrExportNotFound: 			{http.StatusNotFound, "EXPORT_NOT_FOUND"},
+	ErrMediaNotFound: 			{http.StatusNotFound, "MEDIA_NOT_FOUND"},
+	ErrListLimitReached: 		{http.StatusConflict, "LIST_LIMIT_REACHED"},
+	ErrListMemberLimitReached: 	{http.StatusConflict, "LIST_MEMBER_LIMIT_REACHED"},
+	ErrExportInProgress: 		{http.StatusConflict, "EXPORT_IN_PROGRESS"},
+	ErrAlreadyExists: 			{http.StatusConflict, "ALREADY_EXISTS"},
+	ErrEmailTaken: 				{http.StatusConflict, "EMAIL_TAKEN"},
+	ErrInvalidReference: 		{http.StatusUnprocessableEntity, "INVALID_REFERENCE"},
+	ErrExportUnavailable: 		{http.StatusGone, "EXPORT_UNAVAILABLE"},
+	ErrPostSearchUnavailable: 	{http.StatusNotImplemented, "POST_SEARCH_UNAVAILABLE"},
+	ErrInternalServer: 			{http.StatusInternalServerError, ProblemCodeInternal},
+	ErrDatabaseConnection: 		{http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE"},
+	ErrRequestCanceled: 		{StatusClientClosedRequest, "REQUEST_CANCELED"},
+	ErrRequestTimeout: 			{http.StatusServiceUnavailable, "REQUEST_TIMEOUT"},
+
+	media.ErrMissingFile: 			{http.StatusBadRequest, "MISSING_FILE"},
+	media.ErrInvalidImage: 			{http.StatusBadRequest, "INVALID_IMAGE"},
+	media.ErrImageTooLarge: 		{http.StatusBadRequest, "IMAGE_TOO_LARGE"},
+	media.ErrMediaTooLarge: 		{http.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE"},
+	media.ErrUnsupportedMediaType: 	{http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
+	media.ErrInvalidSignature: 		{http.StatusForbidden, "INVALID_SIGNATURE"},
+	dataexport.ErrInvalidSignature: {http.StatusForbidden, "INVALID_SIGNATURE"},
+}
+
+// Postgres error codes the catalogue translates
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation 	= "23505"
+)
+
+// constraintErrors names the violations of specific constraints; any
+// other violation falls back to ErrAlreadyExists or ErrInvalidReference
+var constraintErrors = map[string]error{
+	"users_email_unique": ErrEmailTaken,
+}
+
+// kindProblems covers domain errors wrapping something the catalogue
+// does not list
+var kindProblems = map[Kind]Problem{
+	KindInvalid: 			{http.StatusBadRequest, "INVALID_REQUEST"},
+	KindUnauthenticated: 	{http.StatusUnauthorized, "UNAUTHORIZED"},
+	KindForbidden: 			{http.StatusForbidden, "FORBIDDEN"},
+	KindNotFound: 			{http.StatusNotFound, "NOT_FOUND"},
+	KindConflict: 			{http.StatusConflict, "CONFLICT"},
+}
+
+// Classify returns the catalogue entry of err together with the error
+// whose message may be shown. Database violations are replaced by a
+// sentinel so the SQL behind them never reaches a client. ok is false
+// for errors the catalogue does not know, which are internal.
+func Classify(err error) (problem Problem, public error, ok bool) {
+	var validationErr model.ValidationError
+	if errors.As(err, &validationErr) {
+		return Problem{http.StatusBadRequest, validationErr.Code}, validationErr, true
+	}
+
+	var validationErrPtr *model.ValidationError
+	if errors.As(err, &validationErrPtr) && validationErrPtr != nil {
+		return Problem{http.StatusBadRequest, validationErrPtr.Code}, *validationErrPtr, true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if known, exists := constraintErrors[pqErr.Constraint]; exists {
+			err = known
+		} else if pqErr.Code == uniqueViolation {
+			err = ErrAlreadyExists
+		} else if pqErr.Code == foreignKeyViolation {
+			err = ErrInvalidReference
+		}
+	}
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		if problem, exists := catalogue[current]; exists {
+			return problem, current, true
+		}
+	}
+
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		if problem, exists := kindProblems[domainErr.Kind]; exists {
+			return problem, domainErr.Err, true
+		}
+	}
+
+	return Problem{http.StatusInternalServerError, ProblemCodeInternal}, ErrInternalServer, false
+}
\ No newline at end of file
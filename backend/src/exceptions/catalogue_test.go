@@ -0,0 +1,94 @@
+package exceptions
+
+import (
+	"backend/src/logging"
+	"backend/src/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// ============ Test Cases =============
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name 	string
+		err 	error
+		status 	int
+		code 	string
+		detail 	string
+	}{
+		{"sentinel", ErrListNotFound, http.StatusNotFound, "LIST_NOT_FOUND", ErrListNotFound.Error()},
+		{"wrapped sentinel", fmt.Errorf("loading list: %w", ErrListNotFound), http.StatusNotFound, "LIST_NOT_FOUND", ErrListNotFound.Error()},
+		{"domain error", Forbidden(ErrForbidden), http.StatusForbidden, "FORBIDDEN", ErrForbidden.Error()},
+		{"validation error", Invalid(model.ValidationError{Field: "email", Message: "Email is required", Code: model.ErrCodeRequired}), http.StatusBadRequest, model.ErrCodeRequired, "Email is required"},
+		{"known constraint", fmt.Errorf("creating user: %w", &pq.Error{Code: uniqueViolation, Constraint: "users_email_unique"}), http.StatusConflict, "EMAIL_TAKEN", ErrEmailTaken.Error()},
+		{"unique violation", &pq.Error{Code: uniqueViolation, Constraint: "media_storage_key_unique"}, http.StatusConflict, "ALREADY_EXISTS", ErrAlreadyExists.Error()},
+		{"foreign key violation", &pq.Error{Code: foreignKeyViolation, Constraint: "list_members_user_fk"}, http.StatusUnprocessableEntity, "INVALID_REFERENCE", ErrInvalidReference.Error()},
+		{"unknown error", errors.New("connection refused"), http.StatusInternalServerError, ProblemCodeInternal, ErrInternalServer.Error()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problem, public, _ := Classify(test.err)
+
+			if problem.Status != test.status || problem.Code != test.code {
+				t.Errorf("expected %d %s, got %d %s", test.status, test.code, problem.Status, problem.Code)
+			}
+
+			if public.Error() != test.detail {
+				t.Errorf("expected detail %q, got %q", test.detail, public.Error())
+			}
+		})
+	}
+}
+
+func TestHandleError_WritesProblemDetails(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req = req.WithContext(logging.WithRequest(req.Context(), "request-123"))
+	rr := httptest.NewRecorder()
+
+	HandleError(rr, req, model.ValidationError{Field: "email", Message: "Invalid email format", Code: model.ErrCodeInvalidFormat})
+
+	if contentType := rr.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Errorf("expected content type %q, got %q", ProblemContentType, contentType)
+	}
+
+	var response ProblemResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	expected := ProblemResponse{
+		Type: 		"about:blank",
+		Title: 		"Bad Request",
+		Status: 	http.StatusBadRequest,
+		Detail: 	"Invalid email format",
+		Instance: 	"/users/1",
+		Code: 		model.ErrCodeInvalidFormat,
+		Field: 		"email",
+		RequestID: 	"request-123",
+	}
+	if response != expected {
+		t.Errorf("expected %+v, got %+v", expected, response)
+	}
+}
+
+func TestHandleError_NilErrorIsInternal(t *testing.T) {
+	rr := httptest.NewRecorder()
+	HandleError(rr, httptest.NewRequest("GET", "/users/1", nil), nil)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rr.Code)
+	}
+
+	if !strings.Contains(rr.Body.String(), ProblemCodeInternal) {
+		t.Errorf("expected internal error code, got %s", rr.Body.String())
+	}
+}
\ No newline at end of file
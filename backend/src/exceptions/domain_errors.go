@@ -0,0 +1,62 @@
+package exceptions
+
+import "errors"
+
+// Kind classifies a failure of the service layer in terms every transport
+// can translate: HTTP statuses, gRPC codes or CLI exit codes.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
+	KindConflict
+)
+
+// DomainError is what services return for anything the caller caused. Err
+// is the sentinel or model.ValidationError to show them; the catalogue
+// falls back to Kind when it does not list Err.
+type DomainError struct {
+	Kind 	Kind
+	Err 	error
+}
+
+func (e *DomainError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+func Invalid(err error) error {
+	return &DomainError{Kind: KindInvalid, Err: err}
+}
+
+func Unauthenticated(err error) error {
+	return &DomainError{Kind: KindUnauthenticated, Err: err}
+}
+
+func Forbidden(err error) error {
+	return &DomainError{Kind: KindForbidden, Err: err}
+}
+
+func NotFound(err error) error {
+	return &DomainError{Kind: KindNotFound, Err: err}
+}
+
+func Conflict(err error) error {
+	return &DomainError{Kind: KindConflict, Err: err}
+}
+
+// KindOf returns the kind of err, KindInternal if it has none
+func KindOf(err error) Kind {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+
+	return KindInternal
+}
\ No newline at end of file
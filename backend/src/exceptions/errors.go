@@ -12,4 +12,21 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrUnauthorized = errors.New("unauthorized access")
 	ErrForbidden = errors.New("forbidden access")
+	ErrAccountDeactivated = errors.New("account is deactivated, log in again to restore it")
+	ErrListNotFound = errors.New("list not found")
+	ErrInvalidListID = errors.New("invalid list ID")
+	ErrListLimitReached = errors.New("maximum number of lists reached")
+	ErrListMemberLimitReached = errors.New("maximum number of list members reached")
+	ErrExportNotFound = errors.New("data export not found")
+	ErrInvalidExportID = errors.New("invalid data export ID")
+	ErrExportInProgress = errors.New("a data export is already in progress")
+	ErrExportUnavailable = errors.New("data export expired or already downloaded")
+	ErrExportClaimLost = errors.New("data export was claimed by another worker")
+	ErrRequestCanceled = errors.New("request canceled by the client")
+	ErrRequestTimeout = errors.New("request took too long to complete")
+	ErrMediaNotFound = errors.New("media not found")
+	ErrAlreadyExists = errors.New("resource already exists")
+	ErrEmailTaken = errors.New("email is already registered")
+	ErrInvalidReference = errors.New("referenced resource does not exist")
+	ErrPostSearchUnavailable = errors.New("post search is not available yet")
 )
\ No newline at end of file
@@ -1,72 +1,84 @@
 package exceptions
 
 import (
+	"backend/src/logging"
 	"backend/src/model"
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
-type ErrorResponse struct {
-	URL 	string 	`json:"url"`
-	Type 	int 	`json:"type"`
-	Message string 	`json:"message"`
-}
+// StatusClientClosedRequest is the non-standard code nginx uses when the
+// client goes away before the response is written
+const StatusClientClosedRequest = 499
 
-type ValidationErrorResponse struct {
-	URL     string `json:"url"`
-	Type    int    `json:"type"`
-	Message string `json:"message"`
-	Field   string `json:"field"`
-	Code    string `json:"code"`
-}
+const ProblemContentType = "application/problem+json"
 
-func HandleResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.WriteHeader(statusCode)
+// ProblemResponse is the body of every error, following RFC 7807. Code
+// is the stable machine code from the catalogue; Field is only set for
+// validation errors.
+type ProblemResponse struct {
+	Type 		string 	`json:"type"`
+	Title 		string 	`json:"title"`
+	Status 		int 	`json:"status"`
+	Detail 		string 	`json:"detail"`
+	Instance 	string 	`json:"instance"`
+	Code 		string 	`json:"code"`
+	Field 		string 	`json:"field,omitempty"`
+	RequestID 	string 	`json:"request_id,omitempty"`
+}
 
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Fatal(err)
+// HandleError answers the request with the problem the catalogue maps err
+// to. Errors it does not know are logged and hidden behind a generic 500.
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		err = ErrInternalServer
 	}
-}
 
-func HandleError(
-	w http.ResponseWriter,
-	r *http.Request,
-	statusCode int,
-	err error,
-) {
-	if validationErr, ok := err.(model.ValidationError); ok {
-		errorResponse := ValidationErrorResponse {
-			URL:	 r.URL.Path,
-			Type: 	 statusCode,
-			Message: validationErr.Message,
-			Field:   validationErr.Field,
-			Code:    validationErr.Code,
+	problem, public, known := Classify(err)
+
+	// A query aborted by the request context fails like any other database
+	// error, so report why it was aborted instead of a generic 500
+	if problem.Status >= http.StatusInternalServerError {
+		switch r.Context().Err() {
+		case context.Canceled:
+			problem, public, known = Classify(ErrRequestCanceled)
+		case context.DeadlineExceeded:
+			problem, public, known = Classify(ErrRequestTimeout)
 		}
-		HandleResponse(w, statusCode, errorResponse)
-		return
 	}
 
-	errorResponse := ErrorResponse {
-		URL:	 r.URL.Path,
-		Type: 	 statusCode,
-		Message: err.Error(),
+	if !known {
+		logging.FromContext(r.Context()).Error("Unexpected error", "error", err)
 	}
 
-	HandleResponse(w, statusCode, errorResponse)
+	response := ProblemResponse{
+		Type: 		"about:blank",
+		Title: 		statusTitle(problem.Status),
+		Status: 	problem.Status,
+		Detail: 	public.Error(),
+		Instance: 	r.URL.Path,
+		Code: 		problem.Code,
+		RequestID: 	logging.RequestID(r.Context()),
+	}
+
+	if validationErr, ok := public.(model.ValidationError); ok {
+		response.Field = validationErr.Field
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logging.FromContext(r.Context()).Warn("Error writing error response", "error", err)
+	}
 }
 
-func HandleErrorWithCustomMessage(
-	w http.ResponseWriter,
-	r *http.Request,
-	statuCode int,
-	message string,
-) {
-	errorResponse := ErrorResponse {
-		URL:	 r.URL.Path,
-		Type: 	 statuCode,
-		Message: message,
+// statusTitle is the title RFC 7807 expects with the about:blank type
+func statusTitle(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
 	}
 
-	HandleResponse(w, statuCode, errorResponse)
+	return http.StatusText(status)
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"backend/src/model"
+	"context"
+	"time"
+)
+
+type DataExportRepositoryInterface interface {
+	CreateDataExport(ctx context.Context, userID uint64) (model.DataExport, error)
+	GetLatestDataExport(ctx context.Context, userID uint64) (model.DataExport, error)
+	ClaimDataExportDownload(ctx context.Context, exportID uint64) (string, error)
+	ReleaseDataExportDownload(ctx context.Context, exportID uint64) error
+}
+
+type DataExportProcessingRepositoryInterface interface {
+	ClaimPendingDataExport(ctx context.Context, staleBefore time.Time) (model.DataExport, error)
+	GetUserData(ctx context.Context, userID uint64) (model.UserData, error)
+	CompleteDataExport(ctx context.Context, export model.DataExport, key string, expiresAt time.Time) error
+	FailDataExport(ctx context.Context, export model.DataExport) error
+	ExpireDataExports(ctx context.Context, now time.Time) ([]string, error)
+}
\ No newline at end of file
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"backend/src/model"
+	"context"
+)
+
+type ListRepositoryInterface interface {
+	CreateList(ctx context.Context, list model.List) (model.List, error)
+	GetListsByOwner(ctx context.Context, ownerID uint64) ([]model.List, error)
+	GetListByID(ctx context.Context, listID uint64) (model.List, error)
+	UpdateList(ctx context.Context, listID uint64, list model.List) (model.List, error)
+	DeleteList(ctx context.Context, listID uint64) error
+	GetListMembers(ctx context.Context, listID uint64) ([]model.User, error)
+	AddListMember(ctx context.Context, listID uint64, userID uint64) error
+	RemoveListMember(ctx context.Context, listID uint64, userID uint64) error
+}
\ No newline at end of file
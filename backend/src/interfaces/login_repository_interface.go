@@ -1,7 +1,11 @@
 package interfaces
 
-import "backend/src/model"
+import (
+	"backend/src/model"
+	"context"
+)
 
 type LoginRepositoryInterface interface {
-	GetUserByEmail(email string) (model.LoginUser, error)
+	GetUserByEmail(ctx context.Context, email string) (model.LoginUser, error)
+	ReactivateUser(ctx context.Context, userID uint64) error
 }
\ No newline at end of file
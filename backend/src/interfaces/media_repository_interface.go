@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"backend/src/model"
+	"context"
+)
+
+type MediaRepositoryInterface interface {
+	CreateMedia(ctx context.Context, media model.Media) (model.Media, error)
+}
\ No newline at end of file
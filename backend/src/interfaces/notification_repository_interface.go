@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"backend/src/model"
+	"context"
+)
+
+type NotificationRepositoryInterface interface {
+	CreateNotification(ctx context.Context, notification model.Notification) (bool, error)
+	GetNotifications(ctx context.Context, userID uint64, unreadOnly bool) ([]model.Notification, error)
+	MarkNotificationsAsRead(ctx context.Context, userID uint64, notificationIDs []uint64) (int64, error)
+	GetNotificationPreferences(ctx context.Context, userID uint64) (model.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID uint64, preferences model.NotificationPreferences) error
+}
\ No newline at end of file
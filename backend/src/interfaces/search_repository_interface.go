@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"backend/src/model"
+	"context"
+)
+
+type SearchRepositoryInterface interface {
+	SearchUsers(ctx context.Context, query string, cursor *model.SearchCursor, limit int) ([]model.User, *model.SearchCursor, error)
+}
\ No newline at end of file
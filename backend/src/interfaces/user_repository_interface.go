@@ -1,12 +1,26 @@
 package interfaces
 
-import "backend/src/model"
+import (
+	"backend/src/model"
+	"context"
+	"time"
+)
 
 type UserRepositoryInterface interface {
-	CreateUser(user model.User) (model.User, error)
-	GetAllUsers() ([]model.User, error)
-	GetUserByID(userID uint64) (model.User, error)
-	GetUserByNickname(nickname string) (model.User, error)
-	UpdateUserByID(userID uint64, user model.User) (model.User, error)
-	DeleteUserByID(userID uint64) error
+	CreateUser(ctx context.Context, user model.User) (model.User, error)
+	GetAllUsers(ctx context.Context) ([]model.User, error)
+	GetUserByID(ctx context.Context, userID uint64) (model.User, error)
+	GetUserByNickname(ctx context.Context, nickname string) (model.User, error)
+	UpdateUserByID(ctx context.Context, userID uint64, user model.User) (model.User, error)
+	UpdateUserImage(ctx context.Context, userID uint64, kind string, key string) (string, error)
+	DeactivateUserByID(ctx context.Context, userID uint64) error
+}
+
+type AccountStatusRepositoryInterface interface {
+	IsUserActive(ctx context.Context, userID uint64) (bool, error)
+}
+
+type AccountPurgeRepositoryInterface interface {
+	GetUsersDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]uint64, error)
+	PurgeDeactivatedUser(ctx context.Context, userID uint64, before time.Time) ([]string, error)
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const accountPurgeBatchSize = 100
+
+// NewAccountPurgeJob permanently removes accounts whose deactivation is
+// older than gracePeriod, together with the files they uploaded.
+func NewAccountPurgeJob(
+	repo interfaces.AccountPurgeRepositoryInterface,
+	store storage.BlobStorage,
+	gracePeriod time.Duration,
+	interval time.Duration,
+) Job {
+	return Job{
+		Name: 		"account-purge",
+		Interval: 	interval,
+		Run: func(ctx context.Context) error {
+			return purgeAccounts(ctx, repo, store, time.Now().Add(-gracePeriod))
+		},
+	}
+}
+
+// purgeAccounts keeps going past users that fail to purge, so one broken
+// account at the front of the queue does not hold back every later one.
+// The failures are logged and reported together at the end.
+func purgeAccounts(
+	ctx context.Context,
+	repo interfaces.AccountPurgeRepositoryInterface,
+	store storage.BlobStorage,
+	before time.Time,
+) error {
+	failed := map[uint64]bool{}
+	var errs []error
+
+	for {
+		// Failed users come back in every batch, so ask for that many more
+		limit := accountPurgeBatchSize + len(failed)
+		userIDs, err := repo.GetUsersDeactivatedBefore(ctx, before, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, userID := range userIDs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if failed[userID] {
+				continue
+			}
+
+			keys, err := repo.PurgeDeactivatedUser(ctx, userID, before)
+			if err == exceptions.ErrUserNotFound {
+				continue
+			}
+			if err != nil {
+				slog.Error("Error purging deactivated user", "user_id", userID, "error", err)
+				failed[userID] = true
+				errs = append(errs, fmt.Errorf("user %d: %w", userID, err))
+				continue
+			}
+
+			// The account is already gone, so a file that fails to delete is
+			// only logged and never blocks the rest of the batch
+			for _, key := range keys {
+				if err := store.Delete(key); err != nil {
+					slog.Warn("Error deleting file of purged user", "key", key, "user_id", userID, "error", err)
+				}
+			}
+
+			slog.Info("Purged deactivated user", "user_id", userID)
+		}
+
+		if len(userIDs) < limit {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to purge %d users: %w", len(errs), errors.Join(errs...))
+	}
+
+	return nil
+}
\ No newline at end of file
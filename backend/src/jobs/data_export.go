@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"backend/src/dataexport"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/storage"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// dataExportClaimTimeout is how long an export may stay in processing
+// before another run assumes its worker died and builds it again
+const dataExportClaimTimeout = time.Hour
+
+// NewDataExportJob builds the archives users asked for and cleans up the
+// ones that were downloaded or expired.
+func NewDataExportJob(
+	repo interfaces.DataExportProcessingRepositoryInterface,
+	store storage.BlobStorage,
+	linkExpiration time.Duration,
+	interval time.Duration,
+) Job {
+	return Job{
+		Name: 		"data-export",
+		Interval: 	interval,
+		Run: func(ctx context.Context) error {
+			if err := processDataExports(ctx, repo, store, linkExpiration); err != nil {
+				return err
+			}
+			return expireDataExports(ctx, repo, store)
+		},
+	}
+}
+
+func processDataExports(
+	ctx context.Context,
+	repo interfaces.DataExportProcessingRepositoryInterface,
+	store storage.BlobStorage,
+	linkExpiration time.Duration,
+) error {
+	for ctx.Err() == nil {
+		export, err := repo.ClaimPendingDataExport(ctx, time.Now().Add(-dataExportClaimTimeout))
+		if err == exceptions.ErrExportNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		key, err := buildDataExport(ctx, export.UserID, repo, store)
+		if err != nil {
+			slog.Error("Error building data export", "export_id", export.ID, "error", err)
+			if err := repo.FailDataExport(ctx, export); err != nil && err != exceptions.ErrExportClaimLost {
+				return err
+			}
+			continue
+		}
+
+		if err := repo.CompleteDataExport(ctx, export, key, time.Now().Add(linkExpiration)); err != nil {
+			if err := store.Delete(key); err != nil {
+				slog.Warn("Error deleting orphaned export", "key", key, "error", err)
+			}
+			if err == exceptions.ErrExportClaimLost {
+				slog.Warn("Data export was reclaimed by another worker", "export_id", export.ID)
+				continue
+			}
+			return err
+		}
+
+		slog.Info("Data export is ready", "export_id", export.ID, "user_id", export.UserID)
+	}
+
+	return ctx.Err()
+}
+
+// buildDataExport writes the archive to a temporary file first because
+// blob storage needs to know the size before the upload starts.
+func buildDataExport(
+	ctx context.Context,
+	userID uint64,
+	repo interfaces.DataExportProcessingRepositoryInterface,
+	store storage.BlobStorage,
+) (string, error) {
+	data, err := repo.GetUserData(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := dataexport.WriteArchive(file, data, store); err != nil {
+		return "", err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", fmt.Errorf("failed to measure archive: %w", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("exports/%d/%s.zip", userID, hex.EncodeToString(name))
+	if err := store.Put(key, file, size, "application/zip"); err != nil {
+		return "", fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	return key, nil
+}
+
+func expireDataExports(ctx context.Context, repo interfaces.DataExportProcessingRepositoryInterface, store storage.BlobStorage) error {
+	keys, err := repo.ExpireDataExports(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			slog.Warn("Error deleting expired export", "key", key, "error", err)
+		}
+	}
+
+	return nil
+}
\ No newline at end of file
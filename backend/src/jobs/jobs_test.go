@@ -0,0 +1,357 @@
+package jobs
+
+import (
+	"archive/zip"
+	"backend/src/exceptions"
+	"backend/src/model"
+	"backend/src/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockPurgeRepository struct {
+	deactivated map[uint64]time.Time
+	keys 		map[uint64][]string
+	failing 	map[uint64]bool
+	purged 		[]uint64
+}
+
+func (m *MockPurgeRepository) GetUsersDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]uint64, error) {
+	var userIDs []uint64
+	for userID, deactivatedAt := range m.deactivated {
+		if deactivatedAt.Before(before) && len(userIDs) < limit {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (m *MockPurgeRepository) PurgeDeactivatedUser(ctx context.Context, userID uint64, before time.Time) ([]string, error) {
+	deactivatedAt, exists := m.deactivated[userID]
+	if !exists || !deactivatedAt.Before(before) {
+		return nil, exceptions.ErrUserNotFound
+	}
+	if m.failing[userID] {
+		return nil, errors.New("foreign key violation")
+	}
+	delete(m.deactivated, userID)
+	m.purged = append(m.purged, userID)
+	return m.keys[userID], nil
+}
+
+type MockDataExportRepository struct {
+	pending 	[]model.DataExport
+	processing 	[]model.DataExport
+	claimedAt 	map[uint64]time.Time
+	reclaimed 	map[uint64]bool
+	data 		model.UserData
+	completed 	map[uint64]string
+	expired 	[]string
+}
+
+func (m *MockDataExportRepository) ClaimPendingDataExport(ctx context.Context, staleBefore time.Time) (model.DataExport, error) {
+	if len(m.pending) > 0 {
+		export := m.pending[0]
+		m.pending = m.pending[1:]
+		return export, nil
+	}
+	for i, export := range m.processing {
+		if m.claimedAt[export.ID].Before(staleBefore) {
+			m.processing = append(m.processing[:i], m.processing[i+1:]...)
+			return export, nil
+		}
+	}
+	return model.DataExport{}, exceptions.ErrExportNotFound
+}
+
+func (m *MockDataExportRepository) GetUserData(ctx context.Context, userID uint64) (model.UserData, error) {
+	return m.data, nil
+}
+
+func (m *MockDataExportRepository) CompleteDataExport(ctx context.Context, export model.DataExport, key string, expiresAt time.Time) error {
+	if m.reclaimed[export.ID] {
+		return exceptions.ErrExportClaimLost
+	}
+	m.completed[export.ID] = key
+	return nil
+}
+
+func (m *MockDataExportRepository) FailDataExport(ctx context.Context, export model.DataExport) error {
+	return nil
+}
+
+func (m *MockDataExportRepository) ExpireDataExports(ctx context.Context, now time.Time) ([]string, error) {
+	keys := m.expired
+	m.expired = nil
+	return keys, nil
+}
+
+// ============ Test Cases =============
+
+func TestScheduler_RunsJobUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	scheduler := NewScheduler()
+	scheduler.Register(Job{
+		Name: 		"counter",
+		Interval: 	10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	scheduler.Start()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := scheduler.Stop(ctx); err != nil {
+		t.Fatalf("expected scheduler to stop, got %v", err)
+	}
+
+	stopped := runs.Load()
+	if stopped < 2 {
+		t.Errorf("expected the job to run repeatedly, ran %d times", stopped)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Error("expected no runs after Stop")
+	}
+}
+
+func TestScheduler_StopHonoursDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	scheduler := NewScheduler()
+	scheduler.Register(Job{
+		Name: 		"stuck",
+		Interval: 	time.Hour,
+		Run: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	})
+	scheduler.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := scheduler.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestPurgeAccounts_RemovesExpiredAccountsAndFiles(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	for _, key := range []string{"avatars/1.jpg", "media/1.png", "avatars/2.jpg"} {
+		if err := store.Put(key, strings.NewReader("data"), 4, "image/jpeg"); err != nil {
+			t.Fatalf("failed to store %s: %v", key, err)
+		}
+	}
+
+	now := time.Now()
+	repo := &MockPurgeRepository{
+		deactivated: map[uint64]time.Time{
+			1: now.Add(-31 * 24 * time.Hour),
+			2: now.Add(-24 * time.Hour),
+		},
+		keys: map[uint64][]string{
+			1: {"avatars/1.jpg", "media/1.png"},
+			2: {"avatars/2.jpg"},
+		},
+	}
+
+	job := NewAccountPurgeJob(repo, store, 30*24*time.Hour, time.Hour)
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("expected purge to succeed, got %v", err)
+	}
+
+	if len(repo.purged) != 1 || repo.purged[0] != 1 {
+		t.Errorf("expected only user 1 to be purged, got %v", repo.purged)
+	}
+
+	if _, err := store.Get("avatars/1.jpg"); err != storage.ErrObjectNotFound {
+		t.Errorf("expected avatar of purged user to be deleted, got %v", err)
+	}
+
+	body, err := store.Get("avatars/2.jpg")
+	if err != nil {
+		t.Fatalf("expected avatar of user in grace period to remain, got %v", err)
+	}
+	body.Close()
+}
+
+func TestPurgeAccounts_SkipsUsersThatFail(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	before := time.Now().Add(-31 * 24 * time.Hour)
+	repo := &MockPurgeRepository{
+		deactivated: map[uint64]time.Time{
+			1: before,
+			2: before,
+			3: before,
+		},
+		failing: map[uint64]bool{1: true},
+	}
+
+	job := NewAccountPurgeJob(repo, store, 30*24*time.Hour, time.Hour)
+	err = job.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "user 1") {
+		t.Errorf("expected the failure of user 1 to be reported, got %v", err)
+	}
+
+	if len(repo.purged) != 2 {
+		t.Errorf("expected users 2 and 3 to be purged despite user 1, got %v", repo.purged)
+	}
+}
+
+func TestDataExportJob_BuildsArchive(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	if err := store.Put("images/1/photo.png", strings.NewReader("png"), 3, "image/png"); err != nil {
+		t.Fatalf("failed to store media: %v", err)
+	}
+	if err := store.Put("exports/1/old.zip", strings.NewReader("old"), 3, "application/zip"); err != nil {
+		t.Fatalf("failed to store old export: %v", err)
+	}
+
+	repo := &MockDataExportRepository{
+		pending: []model.DataExport{{ID: 7, UserID: 1, Status: model.DataExportProcessing}},
+		data: model.UserData{
+			User: 	model.User{ID: 1, Nickname: "gopher", Email: "gopher@gmail.com"},
+			Media: 	[]model.Media{{ID: 3, OwnerID: 1, StorageKey: "images/1/photo.png"}},
+		},
+		completed: 	map[uint64]string{},
+		expired: 	[]string{"exports/1/old.zip"},
+	}
+
+	job := NewDataExportJob(repo, store, time.Hour, time.Minute)
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("expected export to succeed, got %v", err)
+	}
+
+	key, ok := repo.completed[7]
+	if !ok {
+		t.Fatal("expected export 7 to be completed")
+	}
+
+	object, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("expected archive to be stored, got %v", err)
+	}
+	body, _ := io.ReadAll(object)
+	object.Close()
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("expected a valid zip, got %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		files[file.Name], _ = io.ReadAll(reader)
+		reader.Close()
+	}
+
+	var user model.User
+	if err := json.Unmarshal(files["profile.json"], &user); err != nil || user.Email != "gopher@gmail.com" {
+		t.Errorf("expected profile.json with the user, got %s", files["profile.json"])
+	}
+
+	if string(files["media/photo.png"]) != "png" {
+		t.Errorf("expected media file in the archive, got %v", files["media/photo.png"])
+	}
+
+	if _, err := store.Get("exports/1/old.zip"); err != storage.ErrObjectNotFound {
+		t.Errorf("expected expired export to be deleted, got %v", err)
+	}
+}
+
+func TestDataExportJob_ReclaimsOnlyStaleExports(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	now := time.Now()
+	repo := &MockDataExportRepository{
+		processing: []model.DataExport{
+			{ID: 8, UserID: 1, Status: model.DataExportProcessing},
+			{ID: 9, UserID: 2, Status: model.DataExportProcessing},
+		},
+		claimedAt: map[uint64]time.Time{
+			8: now.Add(-2 * time.Hour),
+			9: now.Add(-5 * time.Minute),
+		},
+		completed: 	map[uint64]string{},
+	}
+
+	job := NewDataExportJob(repo, store, time.Hour, time.Minute)
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("expected export to succeed, got %v", err)
+	}
+
+	if _, ok := repo.completed[8]; !ok {
+		t.Error("expected export 8, claimed two hours ago, to be built again")
+	}
+
+	if _, ok := repo.completed[9]; ok {
+		t.Error("expected export 9 to be left to the worker that claimed it")
+	}
+}
+
+func TestDataExportJob_DropsArchiveOfLostClaim(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	repo := &MockDataExportRepository{
+		pending: []model.DataExport{
+			{ID: 7, UserID: 1, Status: model.DataExportProcessing},
+			{ID: 8, UserID: 2, Status: model.DataExportProcessing},
+		},
+		reclaimed: 	map[uint64]bool{7: true},
+		completed: 	map[uint64]string{},
+	}
+
+	job := NewDataExportJob(repo, store, time.Hour, time.Minute)
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("expected a lost claim not to fail the run, got %v", err)
+	}
+
+	if _, ok := repo.completed[8]; !ok {
+		t.Error("expected the next export to be built after a lost claim")
+	}
+
+	archives, _ := filepath.Glob(filepath.Join(dir, "exports", "1", "*.zip"))
+	if len(archives) != 0 {
+		t.Errorf("expected no archive left for export 7, got %v", archives)
+	}
+}
\ No newline at end of file
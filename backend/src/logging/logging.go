@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+	requestInfoKey
+)
+
+// requestInfo collects what inner handlers learn about a request so the
+// access log written by the outer middleware can report it.
+type requestInfo struct {
+	userID uint64
+}
+
+// Setup makes a JSON (or text) slog handler the process default. Calls to
+// the standard log package go through the same handler afterwards.
+func Setup(format string, level string) {
+	slog.SetDefault(New(os.Stdout, format, level))
+}
+
+func New(w io.Writer, format string, level string) *slog.Logger {
+	options := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	if strings.EqualFold(format, "text") {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+
+	return slog.New(slog.NewJSONHandler(w, options))
+}
+
+// FromContext returns the logger of the request, already carrying its
+// request ID, or the default logger outside of a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithRequest starts the logging state of a request: its ID, a logger
+// tagged with it and room for the authenticated user.
+func WithRequest(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	ctx = context.WithValue(ctx, requestInfoKey, &requestInfo{})
+	return WithLogger(ctx, FromContext(ctx).With("request_id", requestID))
+}
+
+// SetUserID records the authenticated user for the access log and tags the
+// request logger with it.
+func SetUserID(ctx context.Context, userID uint64) context.Context {
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		info.userID = userID
+	}
+
+	return WithLogger(ctx, FromContext(ctx).With("user_id", userID))
+}
+
+func UserID(ctx context.Context) uint64 {
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		return info.userID
+	}
+
+	return 0
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
\ No newline at end of file
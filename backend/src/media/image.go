@@ -0,0 +1,134 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	MaxUploadSize 	= 10 << 20
+	MaxDimension 	= 8000
+	ThumbnailSize 	= 320
+)
+
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported media type, only JPEG and PNG images are allowed")
+	ErrMediaTooLarge = errors.New("media exceeds the maximum upload size of 10MB")
+	ErrInvalidImage = errors.New("invalid or corrupted image")
+	ErrImageTooLarge = errors.New("image dimensions exceed 8000x8000 pixels")
+)
+
+type ProcessedImage struct {
+	ContentType string
+	Extension 	string
+	Width 		int
+	Height 		int
+	Data 		[]byte
+	Thumbnail 	[]byte
+}
+
+// ProcessImage validates an uploaded image and re-encodes it. Re-encoding
+// drops every metadata block, EXIF included, since only pixels are written,
+// so a JPEG's EXIF orientation is applied to the pixels first.
+func ProcessImage(data []byte) (ProcessedImage, error) {
+	if len(data) > MaxUploadSize {
+		return ProcessedImage{}, ErrMediaTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return ProcessedImage{}, ErrUnsupportedMediaType
+	}
+
+	// Check the header before decoding so huge images never get allocated
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, ErrInvalidImage
+	}
+
+	if imageConfig.Width > MaxDimension || imageConfig.Height > MaxDimension {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, ErrInvalidImage
+	}
+
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	encoded, err := encode(img, contentType)
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+
+	thumbnail, err := encode(resize(img, ThumbnailSize), contentType)
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+
+	processed := ProcessedImage{
+		ContentType: 	contentType,
+		Extension: 		".jpg",
+		Width: 			img.Bounds().Dx(),
+		Height: 		img.Bounds().Dy(),
+		Data: 			encoded,
+		Thumbnail: 		thumbnail,
+	}
+
+	if contentType == "image/png" {
+		processed.Extension = ".png"
+	}
+
+	return processed, nil
+}
+
+// resize scales the image down so its largest side fits maxSide, keeping
+// the aspect ratio. Smaller images are returned untouched.
+func resize(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= maxSide && height <= maxSide {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSide/width)
+		width = maxSide
+	} else {
+		width = max(1, width*maxSide/height)
+		height = maxSide
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Over, nil)
+
+	return resized
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buffer, img)
+	} else {
+		err = jpeg.Encode(&buffer, img, &jpeg.Options{Quality: 90})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
\ No newline at end of file
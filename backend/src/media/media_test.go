@@ -0,0 +1,235 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 Exif segment right after the JPEG SOI marker.
+func withExif(data []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS secret location")...)
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+// withOrientation inserts an APP1 Exif segment whose only IFD0 entry is the
+// Orientation tag, the way cameras mark a rotated shot.
+func withOrientation(data []byte, orientation byte) []byte {
+	payload := []byte("Exif\x00\x00MM\x00\x2A\x00\x00\x00\x08")
+	payload = append(payload, 0x00, 0x01)
+	payload = append(payload, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00)
+	payload = append(payload, 0x00, 0x00, 0x00, 0x00)
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestProcessImage_JPEG(t *testing.T) {
+	var buffer bytes.Buffer
+	jpeg.Encode(&buffer, testImage(640, 480), nil)
+	data := withExif(buffer.Bytes())
+
+	processed, err := ProcessImage(data)
+	if err != nil {
+		t.Fatalf("failed to process image: %v", err)
+	}
+
+	if processed.ContentType != "image/jpeg" || processed.Extension != ".jpg" {
+		t.Errorf("unexpected type %q / %q", processed.ContentType, processed.Extension)
+	}
+
+	if processed.Width != 640 || processed.Height != 480 {
+		t.Errorf("expected 640x480, got %dx%d", processed.Width, processed.Height)
+	}
+
+	if bytes.Contains(processed.Data, []byte("Exif")) {
+		t.Error("expected EXIF metadata to be stripped")
+	}
+
+	thumbnail, _, err := image.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+
+	if thumbnail.Width != ThumbnailSize || thumbnail.Height != 240 {
+		t.Errorf("expected %dx240 thumbnail, got %dx%d", ThumbnailSize, thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestProcessImage_AppliesExifOrientation(t *testing.T) {
+	var buffer bytes.Buffer
+	jpeg.Encode(&buffer, testImage(640, 480), nil)
+	data := withOrientation(buffer.Bytes(), 6)
+
+	processed, err := ProcessImage(data)
+	if err != nil {
+		t.Fatalf("failed to process image: %v", err)
+	}
+
+	if processed.Width != 480 || processed.Height != 640 {
+		t.Errorf("expected the image rotated to 480x640, got %dx%d", processed.Width, processed.Height)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(processed.Data))
+	if err != nil {
+		t.Fatalf("failed to decode image: %v", err)
+	}
+
+	if config.Width != 480 || config.Height != 640 {
+		t.Errorf("expected 480x640 pixels, got %dx%d", config.Width, config.Height)
+	}
+
+	thumbnail, _, err := image.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+
+	if thumbnail.Width != 240 || thumbnail.Height != ThumbnailSize {
+		t.Errorf("expected 240x%d thumbnail, got %dx%d", ThumbnailSize, thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestOrient(t *testing.T) {
+	marker := color.RGBA{255, 0, 0, 255}
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, marker)
+
+	// Where the top-left pixel of a 3x2 image ends up for each orientation
+	cases := map[int]image.Point{
+		1: {0, 0},
+		2: {2, 0},
+		3: {2, 1},
+		4: {0, 1},
+		5: {0, 0},
+		6: {1, 0},
+		7: {1, 2},
+		8: {0, 2},
+	}
+
+	for orientation, expected := range cases {
+		oriented := orient(img, orientation)
+
+		width, height := 3, 2
+		if orientation >= 5 {
+			width, height = 2, 3
+		}
+		if oriented.Bounds().Dx() != width || oriented.Bounds().Dy() != height {
+			t.Errorf("orientation %d: expected %dx%d, got %v", orientation, width, height, oriented.Bounds())
+		}
+
+		if oriented.At(expected.X, expected.Y) != color.Color(marker) {
+			t.Errorf("orientation %d: expected the marker at %v", orientation, expected)
+		}
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	var buffer bytes.Buffer
+	jpeg.Encode(&buffer, testImage(8, 8), nil)
+
+	if orientation := jpegOrientation(withOrientation(buffer.Bytes(), 6)); orientation != 6 {
+		t.Errorf("expected orientation 6, got %d", orientation)
+	}
+
+	if orientation := jpegOrientation(withExif(buffer.Bytes())); orientation != 1 {
+		t.Errorf("expected malformed EXIF to default to 1, got %d", orientation)
+	}
+
+	if orientation := jpegOrientation(buffer.Bytes()); orientation != 1 {
+		t.Errorf("expected a JPEG without EXIF to default to 1, got %d", orientation)
+	}
+}
+
+func TestProcessImage_SmallPNGKeepsSizeForThumbnail(t *testing.T) {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, testImage(50, 100))
+
+	processed, err := ProcessImage(buffer.Bytes())
+	if err != nil {
+		t.Fatalf("failed to process image: %v", err)
+	}
+
+	if processed.ContentType != "image/png" || processed.Extension != ".png" {
+		t.Errorf("unexpected type %q / %q", processed.ContentType, processed.Extension)
+	}
+
+	thumbnail, _, err := image.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+
+	if thumbnail.Width != 50 || thumbnail.Height != 100 {
+		t.Errorf("expected 50x100 thumbnail, got %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestProcessImage_Rejections(t *testing.T) {
+	tests := []struct {
+		name 	string
+		data 	[]byte
+		err 	error
+	}{
+		{"text", []byte("definitely not an image"), ErrUnsupportedMediaType},
+		{"truncated png", []byte("\x89PNG\r\n\x1a\n\x00\x00"), ErrInvalidImage},
+		{"too large", make([]byte, MaxUploadSize+1), ErrMediaTooLarge},
+	}
+
+	for _, test := range tests {
+		if _, err := ProcessImage(test.data); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	signer := NewURLSigner([]byte("test-secret"), time.Minute)
+
+	signed, err := url.Parse(signer.SignedURL("images/1/photo.jpg"))
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %v", err)
+	}
+
+	key := strings.TrimPrefix(signed.Path, "/media/")
+	query := signed.Query()
+
+	if err := signer.VerifySignature(key, query.Get("expires"), query.Get("signature")); err != nil {
+		t.Errorf("expected signature to be valid, got %v", err)
+	}
+
+	if err := signer.VerifySignature("images/2/photo.jpg", query.Get("expires"), query.Get("signature")); err == nil {
+		t.Error("expected signature for another key to be rejected")
+	}
+
+	expired := time.Now().Add(-time.Second).Unix()
+	if err := signer.VerifySignature(key, query.Get("expires"), signer.sign(key, expired)); err == nil {
+		t.Error("expected mismatched expiry to be rejected")
+	}
+
+	if err := signer.VerifySignature(key, "1", signer.sign(key, 1)); err == nil {
+		t.Error("expected expired URL to be rejected")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Leaves room for the multipart boundaries and headers around the file
+const multipartOverhead = 1 << 20
+
+var ErrMissingFile = errors.New("multipart form with a file field is required")
+
+// ReadUpload reads and processes the image sent in the given multipart
+// field. Errors caused by the upload are the sentinels of this package.
+func ReadUpload(w http.ResponseWriter, r *http.Request, field string) (ProcessedImage, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize+multipartOverhead)
+
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ProcessedImage{}, ErrMediaTooLarge
+		}
+		return ProcessedImage{}, ErrMissingFile
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxUploadSize+1))
+	if err != nil {
+		return ProcessedImage{}, ErrInvalidImage
+	}
+
+	processed, err := ProcessImage(data)
+	switch err {
+	case nil, ErrMediaTooLarge, ErrUnsupportedMediaType, ErrInvalidImage, ErrImageTooLarge:
+		return processed, err
+	default:
+		return ProcessedImage{}, fmt.Errorf("failed to process image: %w", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package media
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidSignature = errors.New("invalid or expired media URL")
+
+// URLSigner hands out links to stored media that stop working after
+// expiration, so private files cannot be shared for good.
+type URLSigner struct {
+	secretKey 	[]byte
+	expiration 	time.Duration
+}
+
+func NewURLSigner(secretKey []byte, expiration time.Duration) *URLSigner {
+	return &URLSigner{
+		secretKey: 	secretKey,
+		expiration: expiration,
+	}
+}
+
+func (s *URLSigner) SignedURL(key string) string {
+	expires := time.Now().Add(s.expiration).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, expires))
+
+	return "/media/" + key + "?" + query.Encode()
+}
+
+func (s *URLSigner) VerifySignature(key string, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(s.sign(key, expiresAt)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *URLSigner) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
\ No newline at end of file
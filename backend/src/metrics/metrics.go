@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "social_network"
+
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// Registry holds every metric of the API. A dedicated registry keeps
+// tests free from the global default one.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"http_requests_total",
+		Help: 		"HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: 	namespace,
+		Name: 		"http_request_duration_seconds",
+		Help: 		"HTTP request latency by route template and method.",
+		Buckets: 	prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	LoginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"login_attempts_total",
+		Help: 		"Login attempts by result.",
+	}, []string{"result"})
+
+	UsersRegistered = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"users_registered_total",
+		Help: 		"Accounts created.",
+	})
+
+	UsersDeactivated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"users_deactivated_total",
+		Help: 		"Accounts scheduled for deletion.",
+	})
+
+	ListsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"lists_created_total",
+		Help: 		"User lists created.",
+	})
+
+	MediaUploaded = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"media_uploaded_total",
+		Help: 		"Images uploaded, including avatars and banners.",
+	})
+
+	DataExportsRequested = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: 	namespace,
+		Name: 		"data_exports_requested_total",
+		Help: 		"Data exports requested by users.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		LoginAttempts,
+		UsersRegistered,
+		UsersDeactivated,
+		ListsCreated,
+		MediaUploaded,
+		DataExportsRequested,
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+var handler = promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+
+// Handler serves the metrics in the Prometheus text format
+func Handler(w http.ResponseWriter, r *http.Request) {
+	handler.ServeHTTP(w, r)
+}
\ No newline at end of file
@@ -3,24 +3,241 @@ package middlewares
 import (
 	"backend/src/authentication"
 	"backend/src/exceptions"
-	"log"
+	"backend/src/interfaces"
+	"backend/src/logging"
+	"backend/src/metrics"
+	"backend/src/tracing"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDHeader 	= "X-Request-ID"
+	maxRequestIDLength 	= 128
 )
 
+// statusRecorder remembers what the handler wrote so it can be logged
+type statusRecorder struct {
+	http.ResponseWriter
+	status 	int
+	bytes 	int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(body []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(body)
+	s.bytes += n
+	return n, err
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// RequestID reuses the X-Request-ID sent by the client or a proxy when it
+// looks sane, generates one otherwise, and echoes it in the response.
+func RequestID(next http.HandlerFunc) http.HandlerFunc {
+	return func (w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next(w, r.WithContext(logging.WithRequest(r.Context(), requestID)))
+	}
+}
 
+// Logger writes one access log line per request once the handler returns
 func Logger(next http.HandlerFunc) http.HandlerFunc {
 	return func (w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s %s\n", r.Method, r.RequestURI, r.Host)
-		next(w,r)
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		attributes := []any{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Int("bytes", recorder.bytes),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("remote_addr", r.RemoteAddr),
+		}
+
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				attributes = append(attributes, slog.String("route", template))
+			}
+		}
+
+		if userID := logging.UserID(r.Context()); userID != 0 {
+			attributes = append(attributes, slog.Uint64("user_id", userID))
+		}
+
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		logging.FromContext(r.Context()).Log(r.Context(), level, "request", attributes...)
+	}
+}
+
+// Tracing continues the trace of an incoming traceparent header, or starts
+// a new one, with a server span around the rest of the chain. The trace ID
+// is added to the request logger so logs and traces can be joined.
+func Tracing(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func (w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("request.id", logging.RequestID(ctx)),
+			),
+		)
+		defer span.End()
+
+		if spanContext := span.SpanContext(); spanContext.IsValid() {
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("trace_id", spanContext.TraceID().String()))
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next(recorder, r.WithContext(ctx))
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	}
+}
+
+// Metrics counts requests and observes their latency. Labels use the
+// route template given at registration so /users/1 and /users/2 share
+// one series.
+func Metrics(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func (w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		metrics.HTTPRequests.WithLabelValues(route, r.Method, strconv.Itoa(recorder.status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Timeout puts a deadline on the request context so the queries a handler
+// runs are cancelled once it passes. A zero timeout leaves requests
+// unbounded.
+func Timeout(timeout time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return func (w http.ResponseWriter, r *http.Request) {
+		if timeout <= 0 {
+			next(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// Authenticator admits requests carrying a valid token of an active
+// account. Deactivating an account does not revoke the tokens already
+// issued, so every request checks the account is still active.
+type Authenticator struct {
+	tokens 		*authentication.TokenSigner
+	accounts 	interfaces.AccountStatusRepositoryInterface
+}
+
+func NewAuthenticator(tokens *authentication.TokenSigner, accounts interfaces.AccountStatusRepositoryInterface) *Authenticator {
+	return &Authenticator{
+		tokens: 	tokens,
+		accounts: 	accounts,
 	}
 }
 
-func Authenticate(next http.HandlerFunc) http.HandlerFunc {
+func (a *Authenticator) Authenticate(next http.HandlerFunc) http.HandlerFunc {
 	return func (w http.ResponseWriter, r *http.Request) {
-		if err := authentication.ValidateToken(r); err != nil {
-			exceptions.HandleError(w, r, http.StatusUnauthorized, exceptions.ErrUnauthorized)
+		userID, err := a.tokens.ValidateToken(r)
+		if err != nil {
+			exceptions.HandleError(w, r, exceptions.ErrUnauthorized)
+			return
+		}
+
+		active, err := a.accounts.IsUserActive(r.Context(), userID)
+		if err != nil {
+			exceptions.HandleError(w, r, err)
 			return
 		}
-		next(w,r)
+
+		if !active {
+			exceptions.HandleError(w, r, exceptions.ErrAccountDeactivated)
+			return
+		}
+
+		ctx := logging.SetUserID(authentication.WithUserID(r.Context(), userID), userID)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
 	}
+
+	return true
+}
+
+func newRequestID() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(buffer)
 }
\ No newline at end of file
@@ -0,0 +1,282 @@
+package middlewares
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/logging"
+	"backend/src/metrics"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// ============ Implementation of Mocks and Stubs =============
+
+// MockAccountStatusRepository lists the active accounts
+type MockAccountStatusRepository map[uint64]bool
+
+func (m MockAccountStatusRepository) IsUserActive(ctx context.Context, userID uint64) (bool, error) {
+	return m[userID], nil
+}
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buffer bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(logging.New(&buffer, "json", "info"))
+	t.Cleanup(func() {
+		slog.SetDefault(original)
+	})
+	return &buffer
+}
+
+// ============ Test Cases =============
+
+func TestRequestID_ReusesValidHeader(t *testing.T) {
+	var seen string
+	handler := RequestID(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+
+	if seen != "abc-123" || rr.Header().Get(RequestIDHeader) != "abc-123" {
+		t.Errorf("expected request ID abc-123, got %q in context and %q in response", seen, rr.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestRequestID_GeneratesWhenMissingOrInvalid(t *testing.T) {
+	handler := RequestID(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, header := range []string{"", "has spaces\n", string(make([]byte, maxRequestIDLength+1))} {
+		req := httptest.NewRequest("GET", "/users", nil)
+		req.Header.Set(RequestIDHeader, header)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+
+		requestID := rr.Header().Get(RequestIDHeader)
+		if requestID == "" || requestID == header {
+			t.Errorf("expected a generated request ID for %q, got %q", header, requestID)
+		}
+	}
+}
+
+func TestLogger_WritesAccessLog(t *testing.T) {
+	logs := captureLogs(t)
+	tokens := authentication.NewTokenSigner([]byte("test-secret"))
+
+	token, err := tokens.GenerateToken(42)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	authenticator := NewAuthenticator(tokens, MockAccountStatusRepository{42: true})
+	handler := RequestID(Logger(authenticator.Authenticate(func(w http.ResponseWriter, r *http.Request) {
+		if userID, _ := authentication.ExtractUserID(r); userID != 42 {
+			t.Errorf("expected the handler to see user 42, got %d", userID)
+		}
+		w.WriteHeader(http.StatusTeapot)
+	})))
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(RequestIDHeader, "req-1")
+	handler(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", logs.String(), err)
+	}
+
+	if entry["msg"] != "request" || entry["request_id"] != "req-1" {
+		t.Errorf("unexpected access log: %v", entry)
+	}
+
+	if entry["status"] != float64(http.StatusTeapot) || entry["user_id"] != float64(42) {
+		t.Errorf("expected status and user ID in access log, got %v", entry)
+	}
+
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Errorf("expected latency in access log, got %v", entry)
+	}
+}
+
+func TestAuthenticate_RejectsDeactivatedAccount(t *testing.T) {
+	tokens := authentication.NewTokenSigner([]byte("test-secret"))
+
+	token, err := tokens.GenerateToken(42)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	authenticator := NewAuthenticator(tokens, MockAccountStatusRepository{42: false})
+	handler := authenticator.Authenticate(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler should not run for a deactivated account")
+	})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rr.Code)
+	}
+
+	var response exceptions.ProblemResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.Code != "ACCOUNT_DEACTIVATED" {
+		t.Errorf("expected ACCOUNT_DEACTIVATED, got %q", response.Code)
+	}
+}
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	handler := Metrics("/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	counter := metrics.HTTPRequests.WithLabelValues("/users/{userID}", "GET", "404")
+	before := testutil.ToFloat64(counter)
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		handler(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("expected both requests in one series, got %v", got)
+	}
+
+	rr := httptest.NewRecorder()
+	metrics.Handler(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !bytes.Contains(rr.Body.Bytes(), []byte(`social_network_http_request_duration_seconds_count{method="GET",route="/users/{userID}"}`)) {
+		t.Errorf("expected latency histogram for the route template, got:\n%s", rr.Body.String())
+	}
+}
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	originalProvider := otel.GetTracerProvider()
+	originalPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(originalProvider)
+		otel.SetTextMapPropagator(originalPropagator)
+	}()
+
+	handler := Tracing("/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /users/{userID}" {
+		t.Errorf("expected span named after the route template, got %q", span.Name())
+	}
+
+	if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the incoming trace to be continued, got %s", span.SpanContext().TraceID())
+	}
+
+	if span.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the caller span as parent, got %s", span.Parent().SpanID())
+	}
+
+	if span.Status().Code.String() != "Error" {
+		t.Errorf("expected a 500 to mark the span as failed, got %v", span.Status())
+	}
+}
+
+func TestTimeout_SetsDeadline(t *testing.T) {
+	var hasDeadline bool
+	handler := Timeout(time.Second, func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/users", nil))
+
+	if !hasDeadline {
+		t.Error("expected the request context to carry a deadline")
+	}
+}
+
+func TestTimeout_ZeroDisablesDeadline(t *testing.T) {
+	var hasDeadline bool
+	handler := Timeout(0, func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/users", nil))
+
+	if hasDeadline {
+		t.Error("expected no deadline when the timeout is zero")
+	}
+}
+
+func TestTimeout_ExpiredRequestReturnsServiceUnavailable(t *testing.T) {
+	handler := Timeout(time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		exceptions.HandleError(w, r, r.Context().Err())
+	})
+
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest("GET", "/users", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rr.Code)
+	}
+
+	var response exceptions.ProblemResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.Code != "REQUEST_TIMEOUT" || response.Detail != exceptions.ErrRequestTimeout.Error() {
+		t.Errorf("expected request timeout problem, got %+v", response)
+	}
+}
+
+func TestHandleError_CanceledRequestReturnsClientClosedRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest("GET", "/users", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	exceptions.HandleError(rr, req, exceptions.ErrInternalServer)
+
+	if rr.Code != exceptions.StatusClientClosedRequest {
+		t.Errorf("expected status 499, got %d", rr.Code)
+	}
+}
+
+func TestHandleError_CanceledRequestKeepsClientErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest("GET", "/users", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	exceptions.HandleError(rr, req, exceptions.ErrUserNotFound)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+const (
+	DataExportPending 		= "pending"
+	DataExportProcessing 	= "processing"
+	DataExportReady 		= "ready"
+	DataExportFailed 		= "failed"
+	DataExportExpired 		= "expired"
+)
+
+type DataExport struct {
+	ID				uint64		`json:"id,omitempty"`
+	UserID			uint64		`json:"user_id,omitempty"`
+	Status			string		`json:"status,omitempty"`
+	StorageKey		string		`json:"-"`
+	DownloadURL		string		`json:"download_url,omitempty"`
+	ExpiresAt		*time.Time	`json:"expires_at,omitempty"`
+	DownloadedAt	*time.Time	`json:"downloaded_at,omitempty"`
+	CreatedAt		time.Time	`json:"created_at,omitempty"`
+	ClaimedAt		*time.Time	`json:"-"`
+	CompletedAt		*time.Time	`json:"completed_at,omitempty"`
+}
+
+// UserData is everything stored about a user that goes into their export
+type UserData struct {
+	User						User						`json:"user"`
+	Lists						[]ListExport				`json:"lists"`
+	Notifications				[]Notification				`json:"notifications"`
+	NotificationPreferences		NotificationPreferences		`json:"notification_preferences"`
+	Media						[]Media						`json:"media"`
+}
+
+type ListExport struct {
+	List
+	MemberIDs	[]uint64	`json:"member_ids"`
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxListsPerUser = 20
+	MaxListMembers 	= 500
+)
+
+type List struct {
+	ID				uint64		`json:"id,omitempty"`
+	OwnerID			uint64		`json:"owner_id,omitempty"`
+	Name			string		`json:"name,omitempty"`
+	Description		string		`json:"description"`
+	Private			bool		`json:"private"`
+	MemberCount		int			`json:"member_count"`
+	CreatedAt		time.Time	`json:"created_at,omitempty"`
+}
+
+func (l *List) BeforeSave() error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Description = strings.TrimSpace(l.Description)
+
+	if l.Name == "" {
+		return ValidationError {
+			Field:   "name",
+			Message: "List name is required",
+			Code:    ErrCodeRequired,
+		}
+	}
+
+	if utf8.RuneCountInString(l.Name) > 50 {
+		return ValidationError {
+			Field:   "name",
+			Message: "List name must be at most 50 characters long",
+			Code:    ErrCodeTooLong,
+		}
+	}
+
+	if utf8.RuneCountInString(l.Description) > 160 {
+		return ValidationError {
+			Field:   "description",
+			Message: "List description must be at most 160 characters long",
+			Code:    ErrCodeTooLong,
+		}
+	}
+
+	return nil
+}
+
+// VisibleTo reports whether the user may see the list and its members
+func (l List) VisibleTo(userID uint64) bool {
+	return !l.Private || l.OwnerID == userID
+}
\ No newline at end of file
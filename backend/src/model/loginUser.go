@@ -1,11 +1,15 @@
 package model
 
-import "backend/src/security"
+import (
+	"backend/src/security"
+	"time"
+)
 
 type LoginUser struct {
-	ID		 	uint64 `json:"id,omitempty"`
-	Email		string `json:"email"`
-	Password	string `json:"password"`
+	ID		 		uint64 		`json:"id,omitempty"`
+	Email			string 		`json:"email"`
+	Password		string 		`json:"password"`
+	DeactivatedAt	*time.Time	`json:"-"`
 }
 
 func (l *LoginUser) CheckPassword(passwordHashed string, password string) error {
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Media struct {
+	ID				uint64		`json:"id,omitempty"`
+	OwnerID			uint64		`json:"owner_id,omitempty"`
+	StorageKey		string		`json:"-"`
+	ThumbnailKey	string		`json:"-"`
+	ContentType		string		`json:"content_type,omitempty"`
+	Width			int			`json:"width,omitempty"`
+	Height			int			`json:"height,omitempty"`
+	Size			int64		`json:"size,omitempty"`
+	URL				string		`json:"url,omitempty"`
+	ThumbnailURL	string		`json:"thumbnail_url,omitempty"`
+	CreatedAt		time.Time	`json:"created_at,omitempty"`
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+const (
+	NotificationTypeFollow 	= "follow"
+	NotificationTypeLike 	= "like"
+	NotificationTypeReply 	= "reply"
+	NotificationTypeRepost 	= "repost"
+	NotificationTypeMention = "mention"
+
+	// NotificationTypeExportReady is sent by the system when a data export
+	// can be downloaded; it cannot be turned off in the preferences
+	NotificationTypeExportReady = "export_ready"
+)
+
+var NotificationTypes = []string{
+	NotificationTypeFollow,
+	NotificationTypeLike,
+	NotificationTypeReply,
+	NotificationTypeRepost,
+	NotificationTypeMention,
+}
+
+type Notification struct {
+	ID			uint64		`json:"id,omitempty"`
+	UserID		uint64		`json:"user_id,omitempty"`
+	ActorID		uint64		`json:"actor_id,omitempty"`
+	Type		string		`json:"type,omitempty"`
+	EntityID	uint64		`json:"entity_id,omitempty"`
+	ReadAt		*time.Time	`json:"read_at,omitempty"`
+	CreatedAt	time.Time	`json:"created_at,omitempty"`
+}
+
+// NotificationPreferences maps a notification type to whether the user
+// wants to receive it. Types missing from the map are enabled.
+type NotificationPreferences map[string]bool
+
+func IsValidNotificationType(notificationType string) bool {
+	for _, t := range NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p NotificationPreferences) Validate() error {
+	for notificationType := range p {
+		if !IsValidNotificationType(notificationType) {
+			return ValidationError {
+				Field:   notificationType,
+				Message: "Unknown notification type: " + notificationType,
+				Code:    ErrCodeInvalidFormat,
+			}
+		}
+	}
+
+	return nil
+}
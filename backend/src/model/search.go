@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+)
+
+const (
+	SearchTypeUsers = "users"
+	SearchTypePosts = "posts"
+)
+
+// SearchCursor points at the last result of a page. Rank is kept as the
+// rounded numeric text returned by Postgres so it compares exactly.
+type SearchCursor struct {
+	Rank 	string `json:"r"`
+	ID 		uint64 `json:"id"`
+}
+
+// cursorRank is the plain decimal form Postgres prints numerics in. Go
+// would also parse NaN, Inf, hex floats and digit separators, which the
+// ::numeric cast in the query rejects.
+var cursorRank = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+func (c SearchCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeSearchCursor(encoded string) (SearchCursor, error) {
+	invalid := ValidationError {
+		Field:   "cursor",
+		Message: "Invalid cursor",
+		Code:    ErrCodeInvalidFormat,
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return SearchCursor{}, invalid
+	}
+
+	var cursor SearchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return SearchCursor{}, invalid
+	}
+
+	if !cursorRank.MatchString(cursor.Rank) {
+		return SearchCursor{}, invalid
+	}
+
+	return cursor, nil
+}
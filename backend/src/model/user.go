@@ -2,9 +2,11 @@ package model
 
 import (
 	"backend/src/security"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/badoux/checkmail"
 )
@@ -16,9 +18,32 @@ type User struct {
 	Email   	string		`json:"email,omitempty"`
 	Password 	string		`json:"password,omitempty"`
 	Type		string		`json:"type,omitempty"`
+	Bio			string		`json:"bio,omitempty"`
+	Website		string		`json:"website,omitempty"`
+	Location	string		`json:"location,omitempty"`
+	AvatarKey	string		`json:"-"`
+	BannerKey	string		`json:"-"`
 	CreatedAt 	time.Time	`json:"created_at,omitempty"`
 }
 
+// Profile is the public view of a user, safe to show to anyone
+type Profile struct {
+	ID			uint64		`json:"id"`
+	Username 	string		`json:"username"`
+	Nickname 	string		`json:"nickname"`
+	Bio			string		`json:"bio"`
+	Website		string		`json:"website"`
+	Location	string		`json:"location"`
+	AvatarURL	string		`json:"avatar_url,omitempty"`
+	BannerURL	string		`json:"banner_url,omitempty"`
+	CreatedAt 	time.Time	`json:"created_at"`
+}
+
+const (
+	ProfileImageAvatar = "avatar"
+	ProfileImageBanner = "banner"
+)
+
 type ValidationError struct {
 	Field  	string `json:"field"`
 	Message string `json:"message"`
@@ -58,6 +83,12 @@ func (u *User) validUser(step string) error {
 
 	if err := u.validEmail(); err != nil { return err }
 
+	if err := u.validBio(); err != nil { return err }
+
+	if err := u.validWebsite(); err != nil { return err }
+
+	if err := u.validLocation(); err != nil { return err }
+
 	if step == "register" {
 		if err := u.validPassword(); err != nil { return err }
 	}
@@ -164,6 +195,57 @@ func (u *User) validEmail() error {
 	return nil
 }
 
+func (u *User) validBio() error {
+	if utf8.RuneCountInString(strings.TrimSpace(u.Bio)) > 160 {
+		return ValidationError {
+			Field:   "bio",
+			Message: "Bio must be at most 160 characters long",
+			Code:    ErrCodeTooLong,
+		}
+	}
+
+	return nil
+}
+
+func (u *User) validWebsite() error {
+	website := strings.TrimSpace(u.Website)
+
+	if website == "" {
+		return nil
+	}
+
+	if len(website) > 100 {
+		return ValidationError {
+			Field:   "website",
+			Message: "Website must be at most 100 characters long",
+			Code:    ErrCodeTooLong,
+		}
+	}
+
+	parsed, err := url.ParseRequestURI(website)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ValidationError {
+			Field:   "website",
+			Message: "Website must be a valid http or https URL",
+			Code:    ErrCodeInvalidFormat,
+		}
+	}
+
+	return nil
+}
+
+func (u *User) validLocation() error {
+	if utf8.RuneCountInString(strings.TrimSpace(u.Location)) > 30 {
+		return ValidationError {
+			Field:   "location",
+			Message: "Location must be at most 30 characters long",
+			Code:    ErrCodeTooLong,
+		}
+	}
+
+	return nil
+}
+
 func (u *User) validPassword() error {
 	if u.Password == "" {
 		return ValidationError {
@@ -204,6 +286,9 @@ func (u *User) formatInput(step string) error {
 	u.Username = strings.TrimSpace(u.Username)
 	u.Nickname = strings.TrimSpace(u.Nickname)
 	u.Email = strings.TrimSpace(u.Email)
+	u.Bio = strings.TrimSpace(u.Bio)
+	u.Website = strings.TrimSpace(u.Website)
+	u.Location = strings.TrimSpace(u.Location)
 
 	if step == "register" {
 		passwordHash, err := security.HashPassword(u.Password)
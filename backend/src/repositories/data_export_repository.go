@@ -0,0 +1,497 @@
+package repositories
+
+import (
+	"backend/src/exceptions"
+	"backend/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+type PostgreDataExportRepository struct {
+	db *sql.DB
+}
+
+func NewPostgreDataExportRepository(db *sql.DB) *PostgreDataExportRepository {
+	return &PostgreDataExportRepository{
+		db: db,
+	}
+}
+
+// CreateDataExport queues a new export. Only one export per user can be
+// pending or processing at a time, which a partial unique index enforces.
+func (r *PostgreDataExportRepository) CreateDataExport(ctx context.Context, userID uint64) (_ model.DataExport, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "CreateDataExport")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		INSERT INTO data_exports (user_id)
+		SELECT id FROM users WHERE id = $1 AND deactivated_at IS NULL
+		RETURNING id, user_id, status, created_at
+	`
+
+	var export model.DataExport
+	err = r.db.QueryRowContext(ctx, query, userID).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.DataExport{}, exceptions.ErrUserNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			return model.DataExport{}, exceptions.ErrExportInProgress
+		}
+		return model.DataExport{}, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return export, nil
+}
+
+func (r *PostgreDataExportRepository) GetLatestDataExport(ctx context.Context, userID uint64) (_ model.DataExport, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "GetLatestDataExport")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			id, user_id, status, storage_key, expires_at, downloaded_at, created_at, claimed_at, completed_at
+		FROM
+			data_exports
+		WHERE
+			user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	export, err := scanDataExport(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.DataExport{}, exceptions.ErrExportNotFound
+		}
+		return model.DataExport{}, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// ClaimDataExportDownload marks a ready export as downloaded and returns
+// its storage key. Only the first call for an export that has not expired
+// succeeds, so every archive is served once.
+func (r *PostgreDataExportRepository) ClaimDataExportDownload(ctx context.Context, exportID uint64) (_ string, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "ClaimDataExportDownload")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE data_exports
+		SET downloaded_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1
+			AND status = 'ready'
+			AND downloaded_at IS NULL
+			AND expires_at > CURRENT_TIMESTAMP
+		RETURNING storage_key
+	`
+
+	var key string
+	if err := r.db.QueryRowContext(ctx, query, exportID).Scan(&key); err != nil {
+		if err == sql.ErrNoRows {
+			return "", exceptions.ErrExportUnavailable
+		}
+		return "", fmt.Errorf("failed to claim data export download: %w", err)
+	}
+
+	return key, nil
+}
+
+// ReleaseDataExportDownload undoes ClaimDataExportDownload when the archive
+// could not be served, so the link keeps working until it expires.
+func (r *PostgreDataExportRepository) ReleaseDataExportDownload(ctx context.Context, exportID uint64) (err error) {
+	ctx, span := startSpan(ctx, "data_exports", "ReleaseDataExportDownload")
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, `UPDATE data_exports SET downloaded_at = NULL WHERE id = $1 AND status = 'ready'`, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to release data export download: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingDataExport moves the oldest pending export to processing.
+// SKIP LOCKED lets several instances work through the queue side by side,
+// and exports claimed before staleBefore, e.g. by an instance that crashed,
+// are picked up again.
+func (r *PostgreDataExportRepository) ClaimPendingDataExport(ctx context.Context, staleBefore time.Time) (_ model.DataExport, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "ClaimPendingDataExport")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE data_exports
+		SET status = 'processing', claimed_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE
+				status = 'pending'
+				OR (status = 'processing' AND claimed_at < $1)
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, status, storage_key, expires_at, downloaded_at, created_at, claimed_at, completed_at
+	`
+
+	export, err := scanDataExport(r.db.QueryRowContext(ctx, query, staleBefore))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.DataExport{}, exceptions.ErrExportNotFound
+		}
+		return model.DataExport{}, fmt.Errorf("failed to claim pending data export: %w", err)
+	}
+
+	return export, nil
+}
+
+func (r *PostgreDataExportRepository) GetUserData(ctx context.Context, userID uint64) (_ model.UserData, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "GetUserData")
+	defer func() { endSpan(span, err) }()
+
+	var data model.UserData
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT
+			id, username, nickname, email, type, bio, website, location, avatar_key, banner_key, created_at
+		FROM
+			users
+		WHERE
+			id = $1
+	`, userID).Scan(
+		&data.User.ID,
+		&data.User.Username,
+		&data.User.Nickname,
+		&data.User.Email,
+		&data.User.Type,
+		&data.User.Bio,
+		&data.User.Website,
+		&data.User.Location,
+		&data.User.AvatarKey,
+		&data.User.BannerKey,
+		&data.User.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.UserData{}, exceptions.ErrUserNotFound
+		}
+		return model.UserData{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if data.Lists, err = r.getListExports(ctx, userID); err != nil {
+		return model.UserData{}, err
+	}
+
+	if data.Notifications, err = r.getAllNotifications(ctx, userID); err != nil {
+		return model.UserData{}, err
+	}
+
+	notificationRepo := &PostgreNotificationRepository{db: r.db}
+	data.NotificationPreferences, err = notificationRepo.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return model.UserData{}, err
+	}
+
+	if data.Media, err = r.getMedia(ctx, userID); err != nil {
+		return model.UserData{}, err
+	}
+
+	return data, nil
+}
+
+// CompleteDataExport stores where the archive lives and notifies the user
+// in the same transaction, so a ready export always has its notification.
+// It returns ErrExportClaimLost when another worker reclaimed the export
+// since ClaimPendingDataExport handed it out.
+func (r *PostgreDataExportRepository) CompleteDataExport(ctx context.Context, export model.DataExport, key string, expiresAt time.Time) (err error) {
+	ctx, span := startSpan(ctx, "data_exports", "CompleteDataExport")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE data_exports
+		SET status = 'ready', storage_key = $2, expires_at = $3, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'processing' AND claimed_at = $4
+	`, export.ID, key, expiresAt, export.ClaimedAt)
+	if err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+
+	if err := checkClaim(result); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, actor_id, type, entity_id)
+		VALUES ($1, $1, $2, $3)
+	`, export.UserID, model.NotificationTypeExportReady, export.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create export notification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit data export: %w", err)
+	}
+
+	return nil
+}
+
+// FailDataExport gives up on an export, unless another worker reclaimed it
+// in the meantime, which it reports with ErrExportClaimLost.
+func (r *PostgreDataExportRepository) FailDataExport(ctx context.Context, export model.DataExport) (err error) {
+	ctx, span := startSpan(ctx, "data_exports", "FailDataExport")
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE data_exports
+		SET status = 'failed'
+		WHERE id = $1 AND status = 'processing' AND claimed_at = $2
+	`, export.ID, export.ClaimedAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark data export as failed: %w", err)
+	}
+
+	return checkClaim(result)
+}
+
+// checkClaim tells whether an update fenced by claimed_at still found the
+// export where the claim left it
+func checkClaim(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check data export claim: %w", err)
+	}
+
+	if rows == 0 {
+		return exceptions.ErrExportClaimLost
+	}
+
+	return nil
+}
+
+// ExpireDataExports retires archives that were downloaded or outlived their
+// link and returns their storage keys so the files can be removed.
+func (r *PostgreDataExportRepository) ExpireDataExports(ctx context.Context, now time.Time) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "data_exports", "ExpireDataExports")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE data_exports AS e
+		SET status = 'expired', storage_key = ''
+		FROM (
+			SELECT id, storage_key FROM data_exports
+			WHERE status = 'ready' AND (downloaded_at IS NOT NULL OR expires_at <= $1)
+			FOR UPDATE
+		) AS old
+		WHERE e.id = old.id
+		RETURNING old.storage_key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire data exports: %w", err)
+	}
+
+	defer rows.Close()
+	var keys []string
+
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan data export key: %w", err)
+		}
+
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (r *PostgreDataExportRepository) getListExports(ctx context.Context, userID uint64) ([]model.ListExport, error) {
+	query := `
+		SELECT
+			l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at,
+			COALESCE(array_agg(lm.user_id ORDER BY lm.added_at) FILTER (WHERE lm.user_id IS NOT NULL), '{}')
+		FROM
+			lists l
+			LEFT JOIN list_members lm ON lm.list_id = l.id
+		WHERE
+			l.owner_id = $1
+		GROUP BY l.id
+		ORDER BY l.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	defer rows.Close()
+	lists := []model.ListExport{}
+
+	for rows.Next() {
+		var list model.ListExport
+		var memberIDs []int64
+		if err = rows.Scan(
+			&list.ID,
+			&list.OwnerID,
+			&list.Name,
+			&list.Description,
+			&list.Private,
+			&list.CreatedAt,
+			pq.Array(&memberIDs),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan list: %w", err)
+		}
+
+		list.MemberIDs = make([]uint64, 0, len(memberIDs))
+		for _, memberID := range memberIDs {
+			list.MemberIDs = append(list.MemberIDs, uint64(memberID))
+		}
+		list.MemberCount = len(list.MemberIDs)
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+func (r *PostgreDataExportRepository) getAllNotifications(ctx context.Context, userID uint64) ([]model.Notification, error) {
+	query := `
+		SELECT
+			id, user_id, actor_id, type, COALESCE(entity_id, 0), read_at, created_at
+		FROM
+			notifications
+		WHERE
+			user_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	defer rows.Close()
+	notifications := []model.Notification{}
+
+	for rows.Next() {
+		var notification model.Notification
+		var readAt sql.NullTime
+		if err = rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.ActorID,
+			&notification.Type,
+			&notification.EntityID,
+			&readAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *PostgreDataExportRepository) getMedia(ctx context.Context, userID uint64) ([]model.Media, error) {
+	query := `
+		SELECT
+			id, owner_id, storage_key, thumbnail_key, content_type, width, height, size_bytes, created_at
+		FROM
+			media
+		WHERE
+			owner_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	defer rows.Close()
+	media := []model.Media{}
+
+	for rows.Next() {
+		var item model.Media
+		if err = rows.Scan(
+			&item.ID,
+			&item.OwnerID,
+			&item.StorageKey,
+			&item.ThumbnailKey,
+			&item.ContentType,
+			&item.Width,
+			&item.Height,
+			&item.Size,
+			&item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan media: %w", err)
+		}
+
+		media = append(media, item)
+	}
+
+	return media, nil
+}
+
+func scanDataExport(row *sql.Row) (model.DataExport, error) {
+	var export model.DataExport
+	var expiresAt, downloadedAt, claimedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.StorageKey,
+		&expiresAt,
+		&downloadedAt,
+		&export.CreatedAt,
+		&claimedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return model.DataExport{}, err
+	}
+
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+	if downloadedAt.Valid {
+		export.DownloadedAt = &downloadedAt.Time
+	}
+	if claimedAt.Valid {
+		export.ClaimedAt = &claimedAt.Time
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+
+	return export, nil
+}
\ No newline at end of file
@@ -0,0 +1,367 @@
+package repositories
+
+import (
+	"backend/src/exceptions"
+	"backend/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const foreignKeyViolation = "23503"
+
+type PostgreListRepository struct {
+	db *sql.DB
+}
+
+func NewPostgreListRepository(db *sql.DB) *PostgreListRepository {
+	return &PostgreListRepository{
+		db: db,
+	}
+}
+
+// CreateList locks the owner row so concurrent requests cannot both pass
+// the list count check.
+func (r *PostgreListRepository) CreateList(ctx context.Context, list model.List) (_ model.List, err error) {
+	ctx, span := startSpan(ctx, "lists", "CreateList")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.List{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(l.id)
+		FROM (SELECT id FROM users WHERE id = $1 FOR UPDATE) AS u
+		LEFT JOIN lists l ON l.owner_id = u.id
+		GROUP BY u.id
+	`, list.OwnerID).Scan(&count)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.List{}, exceptions.ErrUserNotFound
+		}
+		return model.List{}, fmt.Errorf("failed to count lists: %w", err)
+	}
+
+	if count >= model.MaxListsPerUser {
+		return model.List{}, exceptions.ErrListLimitReached
+	}
+
+	query := `
+		INSERT INTO lists (owner_id, name, description, is_private)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, owner_id, name, description, is_private, created_at
+	`
+
+	var createdList model.List
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		list.OwnerID,
+		list.Name,
+		list.Description,
+		list.Private,
+	).Scan(
+		&createdList.ID,
+		&createdList.OwnerID,
+		&createdList.Name,
+		&createdList.Description,
+		&createdList.Private,
+		&createdList.CreatedAt,
+	)
+	if err != nil {
+		return model.List{}, fmt.Errorf("failed to create list: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.List{}, fmt.Errorf("failed to commit list: %w", err)
+	}
+
+	return createdList, nil
+}
+
+func (r *PostgreListRepository) GetListsByOwner(ctx context.Context, ownerID uint64) (_ []model.List, err error) {
+	ctx, span := startSpan(ctx, "lists", "GetListsByOwner")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at,
+			(
+				SELECT COUNT(*) FROM list_members m JOIN users u ON u.id = m.user_id
+				WHERE m.list_id = l.id AND u.deactivated_at IS NULL
+			)
+		FROM
+			lists l
+		WHERE
+			l.owner_id = $1
+		ORDER BY l.created_at, l.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	defer rows.Close()
+	var lists []model.List
+
+	for rows.Next() {
+		var list model.List
+		if err = rows.Scan(
+			&list.ID,
+			&list.OwnerID,
+			&list.Name,
+			&list.Description,
+			&list.Private,
+			&list.CreatedAt,
+			&list.MemberCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan list: %w", err)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+func (r *PostgreListRepository) GetListByID(ctx context.Context, listID uint64) (_ model.List, err error) {
+	ctx, span := startSpan(ctx, "lists", "GetListByID")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at,
+			(
+				SELECT COUNT(*) FROM list_members m JOIN users u ON u.id = m.user_id
+				WHERE m.list_id = l.id AND u.deactivated_at IS NULL
+			)
+		FROM
+			lists l
+			JOIN users o ON o.id = l.owner_id
+		WHERE
+			l.id = $1
+			AND o.deactivated_at IS NULL
+	`
+
+	var list model.List
+	err = r.db.QueryRowContext(ctx, query, listID).Scan(
+		&list.ID,
+		&list.OwnerID,
+		&list.Name,
+		&list.Description,
+		&list.Private,
+		&list.CreatedAt,
+		&list.MemberCount,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.List{}, exceptions.ErrListNotFound
+		}
+		return model.List{}, fmt.Errorf("failed to get list by ID: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *PostgreListRepository) UpdateList(ctx context.Context, listID uint64, list model.List) (_ model.List, err error) {
+	ctx, span := startSpan(ctx, "lists", "UpdateList")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE lists
+		SET
+			name = $1,
+			description = $2,
+			is_private = $3,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $4
+		RETURNING id, owner_id, name, description, is_private, created_at
+	`
+
+	var updatedList model.List
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		list.Name,
+		list.Description,
+		list.Private,
+		listID,
+	).Scan(
+		&updatedList.ID,
+		&updatedList.OwnerID,
+		&updatedList.Name,
+		&updatedList.Description,
+		&updatedList.Private,
+		&updatedList.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.List{}, exceptions.ErrListNotFound
+		}
+		return model.List{}, fmt.Errorf("failed to update list by ID: %w", err)
+	}
+
+	return updatedList, nil
+}
+
+func (r *PostgreListRepository) DeleteList(ctx context.Context, listID uint64) (err error) {
+	ctx, span := startSpan(ctx, "lists", "DeleteList")
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM lists WHERE id = $1`, listID)
+	if err != nil {
+		return fmt.Errorf("failed to delete list by ID: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return exceptions.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgreListRepository) GetListMembers(ctx context.Context, listID uint64) (_ []model.User, err error) {
+	ctx, span := startSpan(ctx, "lists", "GetListMembers")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			u.id, u.username, u.nickname, u.type, u.created_at
+		FROM
+			list_members m
+			JOIN users u ON u.id = m.user_id
+		WHERE
+			m.list_id = $1
+			AND u.deactivated_at IS NULL
+		ORDER BY m.added_at, u.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list members: %w", err)
+	}
+
+	defer rows.Close()
+	var users []model.User
+
+	for rows.Next() {
+		var user model.User
+		if err = rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Nickname,
+			&user.Type,
+			&user.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan list member: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// AddListMember is idempotent. The list row is locked so concurrent adds
+// cannot push the list past model.MaxListMembers.
+func (r *PostgreListRepository) AddListMember(ctx context.Context, listID uint64, userID uint64) (err error) {
+	ctx, span := startSpan(ctx, "lists", "AddListMember")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(m.user_id)
+		FROM (SELECT id FROM lists WHERE id = $1 FOR UPDATE) AS l
+		LEFT JOIN list_members m ON m.list_id = l.id
+		GROUP BY l.id
+	`, listID).Scan(&count)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return exceptions.ErrListNotFound
+		}
+		return fmt.Errorf("failed to count list members: %w", err)
+	}
+
+	// A full list still accepts users that are already members
+	if count >= model.MaxListMembers {
+		var exists bool
+		if err := tx.QueryRowContext(
+		ctx,
+			`SELECT EXISTS (SELECT 1 FROM list_members WHERE list_id = $1 AND user_id = $2)`,
+			listID, userID,
+		).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check list member: %w", err)
+		}
+
+		if !exists {
+			return exceptions.ErrListMemberLimitReached
+		}
+
+		return nil
+	}
+
+	query := `
+		INSERT INTO list_members (list_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (list_id, user_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, query, listID, userID); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+			return exceptions.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to add list member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit list member: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgreListRepository) RemoveListMember(ctx context.Context, listID uint64, userID uint64) (err error) {
+	ctx, span := startSpan(ctx, "lists", "RemoveListMember")
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`,
+		listID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove list member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return exceptions.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"backend/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type PostgreMediaRepository struct {
+	db *sql.DB
+}
+
+func NewPostgreMediaRepository(db *sql.DB) *PostgreMediaRepository {
+	return &PostgreMediaRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreMediaRepository) CreateMedia(ctx context.Context, media model.Media) (_ model.Media, err error) {
+	ctx, span := startSpan(ctx, "media", "CreateMedia")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		INSERT INTO media (owner_id, storage_key, thumbnail_key, content_type, width, height, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		media.OwnerID,
+		media.StorageKey,
+		media.ThumbnailKey,
+		media.ContentType,
+		media.Width,
+		media.Height,
+		media.Size,
+	).Scan(
+		&media.ID,
+		&media.CreatedAt,
+	)
+
+	if err != nil {
+		return model.Media{}, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	return media, nil
+}
\ No newline at end of file
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"backend/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const notificationsLimit = 100
+
+type PostgreNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgreNotificationRepository(db *sql.DB) *PostgreNotificationRepository {
+	return &PostgreNotificationRepository{
+		db: db,
+	}
+}
+
+// CreateNotification is the hook other repositories call when an action
+// should notify a user. It reports false when nothing was stored, either
+// because the actor is the recipient or the recipient disabled the type.
+func (r *PostgreNotificationRepository) CreateNotification(ctx context.Context, notification model.Notification) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "notifications", "CreateNotification")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		INSERT INTO notifications (user_id, actor_id, type, entity_id)
+		SELECT $1::integer, $2::integer, $3::varchar, NULLIF($4::integer, 0)
+		WHERE $1::integer <> $2::integer
+		AND NOT EXISTS (
+			SELECT 1 FROM notification_preferences
+			WHERE user_id = $1 AND type = $3 AND enabled = FALSE
+		)
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		notification.UserID,
+		notification.ActorID,
+		notification.Type,
+		notification.EntityID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *PostgreNotificationRepository) GetNotifications(ctx context.Context, userID uint64, unreadOnly bool) (_ []model.Notification, err error) {
+	ctx, span := startSpan(ctx, "notifications", "GetNotifications")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			id, user_id, actor_id, type, COALESCE(entity_id, 0), read_at, created_at
+		FROM
+			notifications
+		WHERE
+			user_id = $1
+			AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly, notificationsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	defer rows.Close()
+	var notifications []model.Notification
+
+	for rows.Next() {
+		var notification model.Notification
+		var readAt sql.NullTime
+		if err = rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.ActorID,
+			&notification.Type,
+			&notification.EntityID,
+			&readAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// MarkNotificationsAsRead marks the given notifications as read, or every
+// unread notification of the user when no IDs are given.
+func (r *PostgreNotificationRepository) MarkNotificationsAsRead(ctx context.Context, userID uint64, notificationIDs []uint64) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "notifications", "MarkNotificationsAsRead")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE notifications
+		SET read_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id = $1
+			AND read_at IS NULL
+			AND (cardinality($2::bigint[]) = 0 OR id = ANY($2::bigint[]))
+	`
+
+	ids := make([]int64, 0, len(notificationIDs))
+	for _, id := range notificationIDs {
+		ids = append(ids, int64(id))
+	}
+
+	result, err := r.db.ExecContext(ctx, query, userID, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *PostgreNotificationRepository) GetNotificationPreferences(ctx context.Context, userID uint64) (_ model.NotificationPreferences, err error) {
+	ctx, span := startSpan(ctx, "notifications", "GetNotificationPreferences")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT
+			type, enabled
+		FROM
+			notification_preferences
+		WHERE
+			user_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	defer rows.Close()
+
+	preferences := model.NotificationPreferences{}
+	for _, notificationType := range model.NotificationTypes {
+		preferences[notificationType] = true
+	}
+
+	for rows.Next() {
+		var notificationType string
+		var enabled bool
+		if err = rows.Scan(&notificationType, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+
+		preferences[notificationType] = enabled
+	}
+
+	return preferences, nil
+}
+
+func (r *PostgreNotificationRepository) UpdateNotificationPreferences(ctx context.Context, userID uint64, preferences model.NotificationPreferences) (err error) {
+	ctx, span := startSpan(ctx, "notifications", "UpdateNotificationPreferences")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		INSERT INTO notification_preferences (user_id, type, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for notificationType, enabled := range preferences {
+		if _, err := tx.ExecContext(ctx, query, userID, notificationType, enabled); err != nil {
+			return fmt.Errorf("failed to update notification preferences: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notification preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"backend/src/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type PostgreSearchRepository struct {
+	db *sql.DB
+}
+
+func NewPostgreSearchRepository(db *sql.DB) *PostgreSearchRepository {
+	return &PostgreSearchRepository{
+		db: db,
+	}
+}
+
+// SearchUsers ranks users by their nickname/username search vector, giving
+// an extra boost to nicknames that start with the query. It fetches one row
+// more than the limit to know whether a next page exists.
+func (r *PostgreSearchRepository) SearchUsers(
+	ctx context.Context,
+	query string,
+	cursor *model.SearchCursor,
+	limit int,
+) (_ []model.User, _ *model.SearchCursor, err error) {
+	ctx, span := startSpan(ctx, "users", "SearchUsers")
+	defer func() { endSpan(span, err) }()
+
+	tsQuery := prefixTSQuery(query)
+	if tsQuery == "" {
+		return nil, nil, nil
+	}
+
+	sqlQuery := `
+		WITH ranked AS (
+			SELECT
+				id, username, nickname, type, created_at,
+				ROUND((
+					ts_rank(search_vector, to_tsquery('simple', $1))
+					+ CASE WHEN lower(nickname) LIKE $2 ESCAPE '\' THEN 1 ELSE 0 END
+				)::numeric, 6) AS rank
+			FROM
+				users
+			WHERE
+				deactivated_at IS NULL
+				AND (
+					search_vector @@ to_tsquery('simple', $1)
+					OR lower(nickname) LIKE $2 ESCAPE '\'
+				)
+		)
+		SELECT
+			id, username, nickname, type, created_at, rank::text
+		FROM
+			ranked
+		WHERE
+			$3::numeric IS NULL
+			OR (rank, id) < ($3::numeric, $4)
+		ORDER BY rank DESC, id DESC
+		LIMIT $5
+	`
+
+	var cursorRank sql.NullString
+	var cursorID uint64
+	if cursor != nil {
+		cursorRank = sql.NullString{String: cursor.Rank, Valid: true}
+		cursorID = cursor.ID
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		sqlQuery,
+		tsQuery,
+		likePrefix(strings.ToLower(strings.TrimSpace(query))),
+		cursorRank,
+		cursorID,
+		limit+1,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	defer rows.Close()
+	var users []model.User
+	var ranks []string
+
+	for rows.Next() {
+		var user model.User
+		var rank string
+		if err = rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Nickname,
+			&user.Type,
+			&user.CreatedAt,
+			&rank,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+
+		users = append(users, user)
+		ranks = append(ranks, rank)
+	}
+
+	if len(users) <= limit {
+		return users, nil, nil
+	}
+
+	users = users[:limit]
+	next := &model.SearchCursor{
+		Rank: 	ranks[limit-1],
+		ID: 	users[limit-1].ID,
+	}
+
+	return users, next, nil
+}
+
+// prefixTSQuery turns free text into a tsquery where every word is matched
+// as a prefix, dropping anything that could be read as tsquery syntax.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_'
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+func likePrefix(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value) + "%"
+}
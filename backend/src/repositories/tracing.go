@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"backend/src/exceptions"
+	"backend/src/tracing"
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan opens a client span for one repository operation, named after
+// the method so traces read like the code.
+func startSpan(ctx context.Context, table string, operation string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, table+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.collection.name", table),
+			attribute.String("db.operation.name", operation),
+		),
+	)
+}
+
+// expectedErrors are outcomes the API answers with a 4xx, not failures
+var expectedErrors = []error{
+	exceptions.ErrUserNotFound,
+	exceptions.ErrListNotFound,
+	exceptions.ErrListLimitReached,
+	exceptions.ErrListMemberLimitReached,
+	exceptions.ErrExportNotFound,
+	exceptions.ErrExportInProgress,
+	exceptions.ErrExportUnavailable,
+}
+
+// endSpan closes the span, flagging it as failed unless err is an expected
+// outcome such as a missing row.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !isExpected(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func isExpected(err error) bool {
+	for _, expected := range expectedErrors {
+		if errors.Is(err, expected) {
+			return true
+		}
+	}
+
+	return false
+}
\ No newline at end of file
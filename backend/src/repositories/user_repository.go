@@ -1,38 +1,46 @@
 package repositories
 
 import (
-	"backend/src/database"
 	"backend/src/exceptions"
 	"backend/src/model"
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type PostgreUserRepository struct {
 	db *sql.DB
 }
 
-func NewPostgreUserRepository() *PostgreUserRepository {
+func NewPostgreUserRepository(db *sql.DB) *PostgreUserRepository {
 	return &PostgreUserRepository{
-		db: database.DB,
+		db: db,
 	}
 }
 
-func (r *PostgreUserRepository) CreateUser(user model.User) (model.User, error) {
+func (r *PostgreUserRepository) CreateUser(ctx context.Context, user model.User) (_ model.User, err error) {
+	ctx, span := startSpan(ctx, "users", "CreateUser")
+	defer func() { endSpan(span, err) }()
+
 	query := `
-		INSERT INTO users (username, nickname, email, password) 
-		VALUES ($1, $2, $3, $4) 
-		RETURNING id, username, nickname, email, password, type, created_at
+		INSERT INTO users (username, nickname, email, password, bio, website, location) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7) 
+		RETURNING id, username, nickname, email, password, type, bio, website, location, created_at
 	`
 
 	var createUser model.User
-	err := r.db.QueryRow(
+	err = r.db.QueryRowContext(
+		ctx,
 		query, 
 		user.Username, 
 		user.Nickname, 
 		user.Email,
 		user.Password,
+		user.Bio,
+		user.Website,
+		user.Location,
 	).Scan(
 		&createUser.ID,
 		&createUser.Username,
@@ -40,6 +48,9 @@ func (r *PostgreUserRepository) CreateUser(user model.User) (model.User, error)
 		&createUser.Email,
 		&createUser.Password,
 		&createUser.Type,
+		&createUser.Bio,
+		&createUser.Website,
+		&createUser.Location,
 		&createUser.CreatedAt,
 	)
 
@@ -50,15 +61,20 @@ func (r *PostgreUserRepository) CreateUser(user model.User) (model.User, error)
 	return createUser, nil
 }
 
-func (r *PostgreUserRepository) GetAllUsers() ([]model.User, error) {
+func (r *PostgreUserRepository) GetAllUsers(ctx context.Context) (_ []model.User, err error) {
+	ctx, span := startSpan(ctx, "users", "GetAllUsers")
+	defer func() { endSpan(span, err) }()
+
 	query := `
 		SELECT 
-			id, username, nickname, email, type, created_at
+			id, username, nickname, email, type, bio, website, location, created_at
 		FROM
 			users
+		WHERE
+			deactivated_at IS NULL
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -74,6 +90,9 @@ func (r *PostgreUserRepository) GetAllUsers() ([]model.User, error) {
 			&user.Nickname,
 			&user.Email,
 			&user.Type,
+			&user.Bio,
+			&user.Website,
+			&user.Location,
 			&user.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -85,23 +104,32 @@ func (r *PostgreUserRepository) GetAllUsers() ([]model.User, error) {
 	return users, nil
 }
 
-func (r *PostgreUserRepository) GetUserByID(userID uint64) (model.User, error) {
+func (r *PostgreUserRepository) GetUserByID(ctx context.Context, userID uint64) (_ model.User, err error) {
+	ctx, span := startSpan(ctx, "users", "GetUserByID")
+	defer func() { endSpan(span, err) }()
+
 	query := `
 		SELECT
-			id, username, nickname, email, type, created_at
+			id, username, nickname, email, type, bio, website, location, avatar_key, banner_key, created_at
 		FROM
 			users
 		WHERE
 			id = $1
+			AND deactivated_at IS NULL
 	`
 
 	var user model.User
-	err := r.db.QueryRow(query, userID).Scan(
+	err = r.db.QueryRowContext(ctx, query, userID).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Nickname,
 		&user.Email,
 		&user.Type,
+		&user.Bio,
+		&user.Website,
+		&user.Location,
+		&user.AvatarKey,
+		&user.BannerKey,
 		&user.CreatedAt,
 	)
 
@@ -115,23 +143,32 @@ func (r *PostgreUserRepository) GetUserByID(userID uint64) (model.User, error) {
 	return user, nil
 }
 
-func (r *PostgreUserRepository) GetUserByNickname(nickname string) (model.User, error) {
+func (r *PostgreUserRepository) GetUserByNickname(ctx context.Context, nickname string) (_ model.User, err error) {
+	ctx, span := startSpan(ctx, "users", "GetUserByNickname")
+	defer func() { endSpan(span, err) }()
+
 	query := `
 		SELECT
-			id, username, nickname, email, type, created_at
+			id, username, nickname, email, type, bio, website, location, avatar_key, banner_key, created_at
 		FROM
 			users
 		WHERE
 			nickname = $1
+			AND deactivated_at IS NULL
 	`
 
 	var user model.User
-	err := r.db.QueryRow(query, strings.TrimSpace(nickname)).Scan(
+	err = r.db.QueryRowContext(ctx, query, strings.TrimSpace(nickname)).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Nickname,
 		&user.Email,
 		&user.Type,
+		&user.Bio,
+		&user.Website,
+		&user.Location,
+		&user.AvatarKey,
+		&user.BannerKey,
 		&user.CreatedAt,
 	)
 
@@ -145,10 +182,13 @@ func (r *PostgreUserRepository) GetUserByNickname(nickname string) (model.User,
 	return user, nil
 }
 
-func (r *PostgreUserRepository) GetUserByEmail(email string) (model.LoginUser, error) {
+func (r *PostgreUserRepository) GetUserByEmail(ctx context.Context, email string) (_ model.LoginUser, err error) {
+	ctx, span := startSpan(ctx, "users", "GetUserByEmail")
+	defer func() { endSpan(span, err) }()
+
 	query := `
 		SELECT
-			id, email, password
+			id, email, password, deactivated_at
 		FROM
 			users
 		WHERE
@@ -156,10 +196,12 @@ func (r *PostgreUserRepository) GetUserByEmail(email string) (model.LoginUser, e
 	`
 
 	var loginUser model.LoginUser
-	err := r.db.QueryRow(query, strings.TrimSpace(email)).Scan(
+	var deactivatedAt sql.NullTime
+	err = r.db.QueryRowContext(ctx, query, strings.TrimSpace(email)).Scan(
 		&loginUser.ID,
 		&loginUser.Email,
 		&loginUser.Password,
+		&deactivatedAt,
 	)
 
 	if err != nil {
@@ -170,27 +212,70 @@ func (r *PostgreUserRepository) GetUserByEmail(email string) (model.LoginUser, e
 		return model.LoginUser{}, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
+	if deactivatedAt.Valid {
+		loginUser.DeactivatedAt = &deactivatedAt.Time
+	}
+
 	return loginUser, nil
 }
 
-func (r *PostgreUserRepository) UpdateUserByID(userID uint64, user model.User) (model.User, error) {
+// ReactivateUser restores an account deactivated within the grace period
+func (r *PostgreUserRepository) ReactivateUser(ctx context.Context, userID uint64) (err error) {
+	ctx, span := startSpan(ctx, "users", "ReactivateUser")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE users
+		SET deactivated_at = NULL
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return exceptions.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgreUserRepository) UpdateUserByID(ctx context.Context, userID uint64, user model.User) (_ model.User, err error) {
+	ctx, span := startSpan(ctx, "users", "UpdateUserByID")
+	defer func() { endSpan(span, err) }()
+
 	query := `
 		UPDATE users
 		SET
 			username = $1,
 			nickname = $2,
-			email = $3
+			email = $3,
+			bio = $4,
+			website = $5,
+			location = $6
 		WHERE
-			id = $4
-		RETURNING id, username, nickname, email, type, created_at
+			id = $7
+			AND deactivated_at IS NULL
+		RETURNING id, username, nickname, email, type, bio, website, location, created_at
 	`
 
 	var updatedUser model.User
-	err := r.db.QueryRow(
+	err = r.db.QueryRowContext(
+		ctx,
 		query,
 		user.Username,
 		user.Nickname,
 		user.Email,
+		user.Bio,
+		user.Website,
+		user.Location,
 		userID,
 	).Scan(
 		&updatedUser.ID,
@@ -198,6 +283,9 @@ func (r *PostgreUserRepository) UpdateUserByID(userID uint64, user model.User) (
 		&updatedUser.Nickname,
 		&updatedUser.Email,
 		&updatedUser.Type,
+		&updatedUser.Bio,
+		&updatedUser.Website,
+		&updatedUser.Location,
 		&updatedUser.CreatedAt,
 	)
 
@@ -211,15 +299,80 @@ func (r *PostgreUserRepository) UpdateUserByID(userID uint64, user model.User) (
 	return updatedUser, nil
 }
 
-func (r *PostgreUserRepository) DeleteUserByID(userID uint64) error {
+// UpdateUserImage points the avatar or banner of the user at a new storage
+// key and returns the previous key so the old object can be removed.
+func (r *PostgreUserRepository) UpdateUserImage(ctx context.Context, userID uint64, kind string, key string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "users", "UpdateUserImage")
+	defer func() { endSpan(span, err) }()
+
+	columns := map[string]string{
+		model.ProfileImageAvatar: "avatar_key",
+		model.ProfileImageBanner: "banner_key",
+	}
+
+	column, ok := columns[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown profile image kind: %s", kind)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE users AS u
+		SET %[1]s = $1
+		FROM (
+			SELECT id, %[1]s AS previous_key FROM users
+			WHERE id = $2 AND deactivated_at IS NULL
+			FOR UPDATE
+		) AS previous
+		WHERE u.id = previous.id
+		RETURNING previous.previous_key
+	`, column)
+
+	var previousKey string
+	err = r.db.QueryRowContext(ctx, query, key, userID).Scan(&previousKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", exceptions.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to update user %s: %w", kind, err)
+	}
+
+	return previousKey, nil
+}
+
+// IsUserActive reports whether the account exists and is not deactivated
+func (r *PostgreUserRepository) IsUserActive(ctx context.Context, userID uint64) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "users", "IsUserActive")
+	defer func() { endSpan(span, err) }()
+
 	query := `
-		DELETE FROM users
-		WHERE id = $1
+		SELECT EXISTS (
+			SELECT 1 FROM users WHERE id = $1 AND deactivated_at IS NULL
+		)
+	`
+
+	var active bool
+	if err = r.db.QueryRowContext(ctx, query, userID).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to check user status: %w", err)
+	}
+
+	return active, nil
+}
+
+// DeactivateUserByID only marks the account as deactivated. The data is
+// removed by PurgeDeactivatedUser once the grace period is over.
+func (r *PostgreUserRepository) DeactivateUserByID(ctx context.Context, userID uint64) (err error) {
+	ctx, span := startSpan(ctx, "users", "DeactivateUserByID")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE users
+		SET deactivated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deactivated_at IS NULL
 	`
 
-	result, err := r.db.Exec(query, userID)
+	result, err := r.db.ExecContext(ctx, query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to delete user by ID: %w", err)
+		return fmt.Errorf("failed to deactivate user by ID: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -232,4 +385,104 @@ func (r *PostgreUserRepository) DeleteUserByID(userID uint64) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *PostgreUserRepository) GetUsersDeactivatedBefore(ctx context.Context, before time.Time, limit int) (_ []uint64, err error) {
+	ctx, span := startSpan(ctx, "users", "GetUsersDeactivatedBefore")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT id
+		FROM users
+		WHERE deactivated_at IS NOT NULL AND deactivated_at < $1
+		ORDER BY deactivated_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deactivated users: %w", err)
+	}
+
+	defer rows.Close()
+	var userIDs []uint64
+
+	for rows.Next() {
+		var userID uint64
+		if err = rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan deactivated user: %w", err)
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// PurgeDeactivatedUser deletes the user row, which cascades to everything
+// the user owns, and returns the storage keys of their files so the caller
+// can remove them. It does nothing if the user logged back in meanwhile.
+func (r *PostgreUserRepository) PurgeDeactivatedUser(ctx context.Context, userID uint64, before time.Time) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "users", "PurgeDeactivatedUser")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var avatarKey, bannerKey string
+	err = tx.QueryRowContext(ctx, `
+		SELECT avatar_key, banner_key
+		FROM users
+		WHERE id = $1 AND deactivated_at IS NOT NULL AND deactivated_at < $2
+		FOR UPDATE
+	`, userID, before).Scan(&avatarKey, &bannerKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, exceptions.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to lock deactivated user: %w", err)
+	}
+
+	var keys []string
+	for _, key := range []string{avatarKey, bannerKey} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT storage_key, thumbnail_key FROM media WHERE owner_id = $1
+		UNION ALL
+		SELECT storage_key, '' FROM data_exports WHERE user_id = $1 AND storage_key <> ''
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user files: %w", err)
+	}
+
+	for rows.Next() {
+		var storageKey, thumbnailKey string
+		if err = rows.Scan(&storageKey, &thumbnailKey); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan user files: %w", err)
+		}
+
+		keys = append(keys, storageKey)
+		if thumbnailKey != "" {
+			keys = append(keys, thumbnailKey)
+		}
+	}
+	rows.Close()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return nil, fmt.Errorf("failed to purge user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit user purge: %w", err)
+	}
+
+	return keys, nil
+}
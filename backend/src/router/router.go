@@ -1,12 +1,18 @@
 package router
 
 import (
+	"backend/src/middlewares"
 	"backend/src/router/routes"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
-func Generate() *mux.Router {
+func Generate(
+	controllers routes.Controllers,
+	authenticator *middlewares.Authenticator,
+	requestTimeout time.Duration,
+) *mux.Router {
 	r := mux.NewRouter()
-	return routes.Config(r)
+	return routes.Config(r, controllers, authenticator, requestTimeout)
 }
\ No newline at end of file
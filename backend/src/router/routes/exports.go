@@ -0,0 +1,28 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/export"
+)
+
+func exportRoutes(controller *controllers.ExportController) []Route {
+	return []Route {
+		{
+			URI: "/users/{userID}/export",
+			Method: "POST",
+			Function: controller.RequestDataExport,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}/export",
+			Method: "GET",
+			Function: controller.GetDataExport,
+			AuthRequired: true,
+		},
+		{
+			URI: "/exports/{exportID}",
+			Method: "GET",
+			Function: controller.DownloadDataExport,
+			AuthRequired: false,
+		},
+	}
+}
\ No newline at end of file
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/health"
+)
+
+func healthRoutes(controller *controllers.HealthController) []Route {
+	return []Route {
+		{
+			URI: "/health/live",
+			Method: "GET",
+			Function: controller.Live,
+			AuthRequired: false,
+		},
+		{
+			URI: "/health/ready",
+			Method: "GET",
+			Function: controller.Ready,
+			AuthRequired: false,
+		},
+	}
+}
\ No newline at end of file
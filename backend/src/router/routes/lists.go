@@ -0,0 +1,58 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/list"
+)
+
+func listRoutes(controller *controllers.ListController) []Route {
+	return []Route {
+		{
+			URI: "/lists",
+			Method: "POST",
+			Function: controller.CreateList,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists",
+			Method: "GET",
+			Function: controller.GetMyLists,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}",
+			Method: "GET",
+			Function: controller.GetList,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}",
+			Method: "PUT",
+			Function: controller.UpdateList,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}",
+			Method: "DELETE",
+			Function: controller.DeleteList,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}/members",
+			Method: "GET",
+			Function: controller.GetListMembers,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}/members/{userID}",
+			Method: "PUT",
+			Function: controller.AddListMember,
+			AuthRequired: true,
+		},
+		{
+			URI: "/lists/{listID}/members/{userID}",
+			Method: "DELETE",
+			Function: controller.RemoveListMember,
+			AuthRequired: true,
+		},
+	}
+}
\ No newline at end of file
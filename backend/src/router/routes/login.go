@@ -4,9 +4,11 @@ import (
 	controllers "backend/src/controllers/login"
 )
 
-var loginRoutes = Route {
-	URI: 			"/login",
-	Method: 		"POST",
-	Function: 		controllers.Login,
-	AuthRequired: 	false,
+func loginRoutes(controller *controllers.LoginController) Route {
+	return Route {
+		URI: 			"/login",
+		Method: 		"POST",
+		Function: 		controller.Login,
+		AuthRequired: 	false,
+	}
 }
\ No newline at end of file
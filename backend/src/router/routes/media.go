@@ -0,0 +1,22 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/media"
+)
+
+func mediaRoutes(controller *controllers.MediaController) []Route {
+	return []Route {
+		{
+			URI: "/media",
+			Method: "POST",
+			Function: controller.UploadMedia,
+			AuthRequired: true,
+		},
+		{
+			URI: "/media/{key:.+}",
+			Method: "GET",
+			Function: controller.ServeMedia,
+			AuthRequired: false,
+		},
+	}
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend/src/metrics"
+)
+
+var metricsRoute = Route {
+	URI: 			"/metrics",
+	Method: 		"GET",
+	Function: 		metrics.Handler,
+	AuthRequired: 	false,
+}
\ No newline at end of file
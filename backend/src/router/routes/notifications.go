@@ -0,0 +1,34 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/notification"
+)
+
+func notificationRoutes(controller *controllers.NotificationController) []Route {
+	return []Route {
+		{
+			URI: "/notifications",
+			Method: "GET",
+			Function: controller.GetNotifications,
+			AuthRequired: true,
+		},
+		{
+			URI: "/notifications/read",
+			Method: "POST",
+			Function: controller.MarkNotificationsAsRead,
+			AuthRequired: true,
+		},
+		{
+			URI: "/notifications/preferences",
+			Method: "GET",
+			Function: controller.GetNotificationPreferences,
+			AuthRequired: true,
+		},
+		{
+			URI: "/notifications/preferences",
+			Method: "PUT",
+			Function: controller.UpdateNotificationPreferences,
+			AuthRequired: true,
+		},
+	}
+}
\ No newline at end of file
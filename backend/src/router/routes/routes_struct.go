@@ -1,8 +1,17 @@
 package routes
 
 import (
+	export "backend/src/controllers/export"
+	health "backend/src/controllers/health"
+	list "backend/src/controllers/list"
+	login "backend/src/controllers/login"
+	media "backend/src/controllers/media"
+	notification "backend/src/controllers/notification"
+	search "backend/src/controllers/search"
+	user "backend/src/controllers/user"
 	"backend/src/middlewares"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -14,21 +23,51 @@ type Route struct {
 	AuthRequired 	bool 
 }
 
-func Config(r *mux.Router) *mux.Router {
-	routes := userRoutes
-	routes = append(routes, loginRoutes)
+// Controllers holds the handlers the routes are bound to
+type Controllers struct {
+	Users 			*user.UserController
+	Login 			*login.LoginController
+	Notifications 	*notification.NotificationController
+	Search 			*search.SearchController
+	Media 			*media.MediaController
+	Lists 			*list.ListController
+	Exports 		*export.ExportController
+	Health 			*health.HealthController
+}
 
-	for _, route := range routes {
+func Config(
+	r *mux.Router,
+	controllers Controllers,
+	authenticator *middlewares.Authenticator,
+	requestTimeout time.Duration,
+) *mux.Router {
+	routes := userRoutes(controllers.Users)
+	routes = append(routes, loginRoutes(controllers.Login))
+	routes = append(routes, notificationRoutes(controllers.Notifications)...)
+	routes = append(routes, searchRoutes(controllers.Search))
+	routes = append(routes, mediaRoutes(controllers.Media)...)
+	routes = append(routes, listRoutes(controllers.Lists)...)
+	routes = append(routes, exportRoutes(controllers.Exports)...)
+	routes = append(routes, healthRoutes(controllers.Health)...)
+	routes = append(routes, metricsRoute)
 
+	for _, route := range routes {
+		handler := route.Function
 		if route.AuthRequired {
-			r.HandleFunc(
-				route.URI,
-				middlewares.Logger(
-					middlewares.Authenticate(route.Function),
-				)).Methods(route.Method)
-		} else {
-			r.HandleFunc(route.URI, middlewares.Logger(route.Function)).Methods(route.Method)
+			handler = authenticator.Authenticate(handler)
 		}
+
+		r.HandleFunc(
+			route.URI,
+			middlewares.RequestID(
+				middlewares.Tracing(route.URI,
+					middlewares.Logger(
+						middlewares.Metrics(route.URI,
+							middlewares.Timeout(requestTimeout, handler),
+						),
+					),
+				),
+			)).Methods(route.Method)
 	}
 
 	return r
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	controllers "backend/src/controllers/search"
+)
+
+func searchRoutes(controller *controllers.SearchController) Route {
+	return Route {
+		URI: 			"/search",
+		Method: 		"GET",
+		Function: 		controller.Search,
+		AuthRequired: 	true,
+	}
+}
\ No newline at end of file
@@ -4,41 +4,61 @@ import (
 	controllers "backend/src/controllers/user"
 )
 
-var userRoutes = []Route {
-	{
-		URI: "/users",
-		Method: "POST",
-		Function: controllers.CreateUser,
-		AuthRequired: false,
-	},
-	{
-		URI: "/users",
-		Method: "GET",
-		Function: controllers.GetAllUsers,
-		AuthRequired: true,
-	},
-	{
-		URI: "/users/{userID}",
-		Method: "GET",
-		Function: controllers.GetUserByID,
-		AuthRequired: true,
-	},
-	{
-		URI: "/users/nickname/{nickname}",
-		Method: "GET",
-		Function: controllers.GetUserByNickname,
-		AuthRequired: true,
-	},
-	{
-		URI: "/users/{userID}",
-		Method: "PUT",
-		Function: controllers.UpdateUserByID,
-		AuthRequired: true,
-	},
-	{
-		URI: "/users/{userID}",
-		Method: "DELETE",
-		Function: controllers.DeleteUserByID,
-		AuthRequired: true,
-	},
+func userRoutes(controller *controllers.UserController) []Route {
+	return []Route {
+		{
+			URI: "/users",
+			Method: "POST",
+			Function: controller.CreateUser,
+			AuthRequired: false,
+		},
+		{
+			URI: "/users",
+			Method: "GET",
+			Function: controller.GetAllUsers,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}",
+			Method: "GET",
+			Function: controller.GetUserByID,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/nickname/{nickname}",
+			Method: "GET",
+			Function: controller.GetUserByNickname,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}",
+			Method: "PUT",
+			Function: controller.UpdateUserByID,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}",
+			Method: "DELETE",
+			Function: controller.DeleteUserByID,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}/profile",
+			Method: "GET",
+			Function: controller.GetUserProfile,
+			AuthRequired: false,
+		},
+		{
+			URI: "/users/{userID}/avatar",
+			Method: "PUT",
+			Function: controller.UploadUserAvatar,
+			AuthRequired: true,
+		},
+		{
+			URI: "/users/{userID}/banner",
+			Method: "PUT",
+			Function: controller.UploadUserBanner,
+			AuthRequired: true,
+		},
+	}
 }
\ No newline at end of file
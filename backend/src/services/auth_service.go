@@ -0,0 +1,71 @@
+package services
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/metrics"
+	"context"
+	"fmt"
+	"time"
+)
+
+// AuthService issues tokens; a deactivated account logging in within
+// deletionGracePeriod is reactivated.
+type AuthService struct {
+	repo 				interfaces.LoginRepositoryInterface
+	tokens 				*authentication.TokenSigner
+	deletionGracePeriod time.Duration
+}
+
+func NewAuthService(
+	repo interfaces.LoginRepositoryInterface,
+	tokens *authentication.TokenSigner,
+	deletionGracePeriod time.Duration,
+) *AuthService {
+	return &AuthService{
+		repo: 				 repo,
+		tokens: 			 tokens,
+		deletionGracePeriod: deletionGracePeriod,
+	}
+}
+
+// Login returns a token for the account behind email. An unknown email
+// and a wrong password fail the same way so neither can be probed.
+func (s *AuthService) Login(ctx context.Context, email string, password string) (string, error) {
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if err == exceptions.ErrUserNotFound {
+			return "", loginFailed()
+		}
+		return "", fmt.Errorf("retrieving user: %w", err)
+	}
+
+	if err := user.CheckPassword(user.Password, password); err != nil {
+		return "", loginFailed()
+	}
+
+	if user.DeactivatedAt != nil {
+		// Past the grace period the account only waits for the purge job
+		if time.Since(*user.DeactivatedAt) > s.deletionGracePeriod {
+			return "", loginFailed()
+		}
+
+		if err := s.repo.ReactivateUser(ctx, user.ID); err != nil {
+			return "", fmt.Errorf("reactivating user: %w", err)
+		}
+	}
+
+	token, err := s.tokens.GenerateToken(user.ID)
+	if err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+
+	metrics.LoginAttempts.WithLabelValues(metrics.LoginSuccess).Inc()
+	return token, nil
+}
+
+func loginFailed() error {
+	metrics.LoginAttempts.WithLabelValues(metrics.LoginFailure).Inc()
+	return exceptions.Unauthenticated(exceptions.ErrInvalidCredentials)
+}
\ No newline at end of file
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"backend/src/authentication"
+	"backend/src/exceptions"
+	"backend/src/media"
+	"backend/src/model"
+	"backend/src/security"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============ Implementation of Mocks and Stubs =============
+
+type MockUserRepository struct {
+	users 	map[uint64]model.User
+	updates int
+	failGet bool
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user model.User) (model.User, error) {
+	user.ID = uint64(len(m.users) + 1)
+	m.users[user.ID] = user
+	return user, nil
+}
+
+func (m *MockUserRepository) GetAllUsers(ctx context.Context) ([]model.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID uint64) (model.User, error) {
+	if m.failGet {
+		return model.User{}, errors.New("connection refused")
+	}
+	user, exists := m.users[userID]
+	if !exists {
+		return model.User{}, exceptions.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *MockUserRepository) GetUserByNickname(ctx context.Context, nickname string) (model.User, error) {
+	return model.User{}, exceptions.ErrUserNotFound
+}
+
+func (m *MockUserRepository) UpdateUserByID(ctx context.Context, userID uint64, user model.User) (model.User, error) {
+	m.updates++
+	m.users[userID] = user
+	return user, nil
+}
+
+func (m *MockUserRepository) UpdateUserImage(ctx context.Context, userID uint64, kind string, key string) (string, error) {
+	return "", nil
+}
+
+func (m *MockUserRepository) DeactivateUserByID(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+type MockLoginRepository struct {
+	users map[string]model.LoginUser
+}
+
+func (m *MockLoginRepository) GetUserByEmail(ctx context.Context, email string) (model.LoginUser, error) {
+	user, exists := m.users[email]
+	if !exists {
+		return model.LoginUser{}, exceptions.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *MockLoginRepository) ReactivateUser(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func newUserService() (*UserService, *MockUserRepository) {
+	repo := &MockUserRepository{users: map[uint64]model.User{
+		1: {ID: 1, Username: "test", Nickname: "Test User", Email: "test@gmail.com"},
+	}}
+	return NewUserService(repo, nil, media.NewURLSigner([]byte("test-secret"), time.Minute), 30*24*time.Hour), repo
+}
+
+// ============ Test Cases =============
+
+func TestUpdate_RejectsAnotherUser(t *testing.T) {
+	service, repo := newUserService()
+
+	changes := model.User{Username: "test", Nickname: "Test User", Email: "test@gmail.com"}
+	err := service.Update(context.Background(), 2, 1, changes)
+
+	if exceptions.KindOf(err) != exceptions.KindForbidden {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+
+	if repo.updates != 0 {
+		t.Error("expected the repository not to be called")
+	}
+}
+
+func TestUpdate_ReturnsValidationError(t *testing.T) {
+	service, _ := newUserService()
+
+	changes := model.User{Username: "test", Nickname: "Test User", Email: "not-an-email"}
+	err := service.Update(context.Background(), 1, 1, changes)
+
+	if exceptions.KindOf(err) != exceptions.KindInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+
+	var validationErr model.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "email" {
+		t.Errorf("expected validation error on email, got %v", err)
+	}
+}
+
+func TestGetByID_ClassifiesErrors(t *testing.T) {
+	service, repo := newUserService()
+
+	_, err := service.GetByID(context.Background(), 42)
+	if exceptions.KindOf(err) != exceptions.KindNotFound || !errors.Is(err, exceptions.ErrUserNotFound) {
+		t.Errorf("expected user not found, got %v", err)
+	}
+
+	repo.failGet = true
+	_, err = service.GetByID(context.Background(), 1)
+	if exceptions.KindOf(err) != exceptions.KindInternal {
+		t.Errorf("expected internal error, got %v", err)
+	}
+}
+
+func TestLogin_UnknownEmailLooksLikeWrongPassword(t *testing.T) {
+	hashedPassword, err := security.HashPassword("arrozdoce")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	repo := &MockLoginRepository{users: map[string]model.LoginUser{
+		"teste@gmail.com": {ID: 1, Email: "teste@gmail.com", Password: string(hashedPassword)},
+	}}
+	service := NewAuthService(repo, authentication.NewTokenSigner([]byte("test-secret")), 30*24*time.Hour)
+
+	_, unknownErr := service.Login(context.Background(), "other@gmail.com", "arrozdoce")
+	_, wrongErr := service.Login(context.Background(), "teste@gmail.com", "wrong")
+
+	for _, err := range []error{unknownErr, wrongErr} {
+		if exceptions.KindOf(err) != exceptions.KindUnauthenticated || !errors.Is(err, exceptions.ErrInvalidCredentials) {
+			t.Errorf("expected invalid credentials, got %v", err)
+		}
+	}
+
+	token, err := service.Login(context.Background(), "teste@gmail.com", "arrozdoce")
+	if err != nil || token == "" {
+		t.Errorf("expected a token, got %q (%v)", token, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"backend/src/exceptions"
+	"backend/src/interfaces"
+	"backend/src/logging"
+	"backend/src/media"
+	"backend/src/metrics"
+	"backend/src/model"
+	"backend/src/storage"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UserService holds the rules around accounts and profiles. actorID is
+// always the authenticated user making the call, however the transport
+// authenticated them.
+type UserService struct {
+	repo 				interfaces.UserRepositoryInterface
+	store 				storage.BlobStorage
+	urls 				*media.URLSigner
+	deletionGracePeriod time.Duration
+}
+
+func NewUserService(
+	repo interfaces.UserRepositoryInterface,
+	store storage.BlobStorage,
+	urls *media.URLSigner,
+	deletionGracePeriod time.Duration,
+) *UserService {
+	return &UserService{
+		repo: 				 repo,
+		store: 				 store,
+		urls: 				 urls,
+		deletionGracePeriod: deletionGracePeriod,
+	}
+}
+
+func (s *UserService) Register(ctx context.Context, user model.User) (model.User, error) {
+	if err := user.BeforeCreate("register"); err != nil {
+		return model.User{}, exceptions.Invalid(err)
+	}
+
+	user, err := s.repo.CreateUser(ctx, user)
+	if err != nil {
+		return model.User{}, fmt.Errorf("creating user: %w", err)
+	}
+
+	metrics.UsersRegistered.Inc()
+	return user, nil
+}
+
+func (s *UserService) List(ctx context.Context) ([]model.User, error) {
+	users, err := s.repo.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (s *UserService) GetByID(ctx context.Context, userID uint64) (model.User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.User{}, notFoundOr(err, "retrieving user by ID")
+	}
+
+	if user == (model.User{}) {
+		return model.User{}, exceptions.NotFound(exceptions.ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetByNickname(ctx context.Context, nickname string) (model.User, error) {
+	if nickname == "" {
+		return model.User{}, exceptions.Invalid(exceptions.ErrInvalidUserNickname)
+	}
+
+	user, err := s.repo.GetUserByNickname(ctx, nickname)
+	if err != nil {
+		return model.User{}, notFoundOr(err, "retrieving user by nickname")
+	}
+
+	return user, nil
+}
+
+// GetProfile returns the public view of a user, with signed links to
+// their images
+func (s *UserService) GetProfile(ctx context.Context, userID uint64) (model.Profile, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return model.Profile{}, err
+	}
+
+	profile := model.Profile{
+		ID: 		user.ID,
+		Username: 	user.Username,
+		Nickname: 	user.Nickname,
+		Bio: 		user.Bio,
+		Website: 	user.Website,
+		Location: 	user.Location,
+		CreatedAt: 	user.CreatedAt,
+	}
+
+	if user.AvatarKey != "" {
+		profile.AvatarURL = s.urls.SignedURL(user.AvatarKey)
+	}
+
+	if user.BannerKey != "" {
+		profile.BannerURL = s.urls.SignedURL(user.BannerKey)
+	}
+
+	return profile, nil
+}
+
+// CheckOwner fails unless actorID may change the account userID. The
+// methods below check it themselves; transports call it to refuse early,
+// before reading a large request body.
+func (s *UserService) CheckOwner(actorID uint64, userID uint64) error {
+	if actorID != userID {
+		return exceptions.Forbidden(exceptions.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *UserService) Update(ctx context.Context, actorID uint64, userID uint64, changes model.User) error {
+	if err := s.CheckOwner(actorID, userID); err != nil {
+		return err
+	}
+
+	if err := changes.BeforeCreate("update"); err != nil {
+		return exceptions.Invalid(err)
+	}
+
+	if _, err := s.repo.UpdateUserByID(ctx, userID, changes); err != nil {
+		return notFoundOr(err, "updating user by ID")
+	}
+
+	return nil
+}
+
+// Deactivate only hides the account: logging in before the returned time
+// restores it, after that the purge job removes it for good.
+func (s *UserService) Deactivate(ctx context.Context, actorID uint64, userID uint64) (time.Time, error) {
+	if err := s.CheckOwner(actorID, userID); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := s.repo.DeactivateUserByID(ctx, userID); err != nil {
+		return time.Time{}, notFoundOr(err, "deactivating user")
+	}
+
+	metrics.UsersDeactivated.Inc()
+	return time.Now().Add(s.deletionGracePeriod), nil
+}
+
+// UpdateProfileImage stores the avatar as the processed thumbnail, which
+// is large enough for it, and the banner at full size. It returns a
+// signed link to the new image and deletes the one it replaces.
+func (s *UserService) UpdateProfileImage(
+	ctx context.Context,
+	actorID uint64,
+	userID uint64,
+	kind string,
+	processed media.ProcessedImage,
+) (string, error) {
+	if err := s.CheckOwner(actorID, userID); err != nil {
+		return "", err
+	}
+
+	data := processed.Data
+	if kind == model.ProfileImageAvatar {
+		data = processed.Thumbnail
+	}
+
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("generating profile %s key: %w", kind, err)
+	}
+
+	key := fmt.Sprintf("profiles/%d/%s_%s%s", userID, kind, hex.EncodeToString(suffix), processed.Extension)
+	if err := s.store.Put(key, bytes.NewReader(data), int64(len(data)), processed.ContentType); err != nil {
+		return "", fmt.Errorf("storing profile %s: %w", kind, err)
+	}
+
+	previousKey, err := s.repo.UpdateUserImage(ctx, userID, kind, key)
+	if err != nil {
+		s.store.Delete(key)
+		return "", notFoundOr(err, "updating profile "+kind)
+	}
+
+	if previousKey != "" {
+		if err := s.store.Delete(previousKey); err != nil {
+			logging.FromContext(ctx).Warn("Error deleting previous profile image", "kind", kind, "error", err)
+		}
+	}
+
+	metrics.MediaUploaded.Inc()
+	return s.urls.SignedURL(key), nil
+}
+
+// notFoundOr turns the repository's missing user into a domain error and
+// gives any other failure the context of what was being done
+func notFoundOr(err error, doing string) error {
+	if err == exceptions.ErrUserNotFound {
+		return exceptions.NotFound(err)
+	}
+
+	return fmt.Errorf("%s: %w", doing, err)
+}
\ No newline at end of file
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"backend/src/config"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// BlobStorage stores binary objects under slash separated keys such as
+// "images/1/3f9a.jpg".
+type BlobStorage interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// New builds the storage backend selected by settings.Driver.
+func New(settings config.StorageConfig) (BlobStorage, error) {
+	switch settings.Driver {
+	case "local":
+		return NewLocalStorage(settings.LocalPath)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint: 	settings.S3.Endpoint,
+			Region: 	settings.S3.Region,
+			Bucket: 	settings.S3.Bucket,
+			AccessKey: 	settings.S3.AccessKey,
+			SecretKey: 	settings.S3.SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q", settings.Driver)
+	}
+}
+
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
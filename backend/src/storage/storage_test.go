@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ============ Implementation of Mocks and Stubs =============
+
+// fakeS3 is a local stand-in for an S3 compatible service. It only keeps
+// objects in memory and checks that requests carry a SigV4 signature.
+type fakeS3 struct {
+	mu 		sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3() *httptest.Server {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	return httptest.NewServer(fake)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/") ||
+		!strings.Contains(authorization, "/us-east-1/s3/aws4_request") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ============ Test Cases =============
+
+func exerciseStorage(t *testing.T, store BlobStorage) {
+	t.Helper()
+
+	if err := store.Put("images/1/photo.jpg", strings.NewReader("content"), 7, "image/jpeg"); err != nil {
+		t.Fatalf("failed to put object: %v", err)
+	}
+
+	object, err := store.Get("images/1/photo.jpg")
+	if err != nil {
+		t.Fatalf("failed to get object: %v", err)
+	}
+	body, _ := io.ReadAll(object)
+	object.Close()
+
+	if string(body) != "content" {
+		t.Errorf("expected %q, got %q", "content", string(body))
+	}
+
+	if err := store.Delete("images/1/photo.jpg"); err != nil {
+		t.Fatalf("failed to delete object: %v", err)
+	}
+
+	if _, err := store.Get("images/1/photo.jpg"); err != ErrObjectNotFound {
+		t.Errorf("expected ErrObjectNotFound after delete, got %v", err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+
+	exerciseStorage(t, store)
+}
+
+func TestLocalStorage_RejectsInvalidKeys(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "images/../../secret", "images//photo.jpg"} {
+		if err := store.Put(key, strings.NewReader("x"), 1, "text/plain"); err != ErrInvalidKey {
+			t.Errorf("expected ErrInvalidKey for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	server := newFakeS3()
+	defer server.Close()
+
+	store, err := NewS3Storage(S3Options{
+		Endpoint: 	server.URL,
+		Bucket: 	"media",
+		AccessKey: 	"access",
+		SecretKey: 	"secret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create s3 storage: %v", err)
+	}
+
+	exerciseStorage(t, store)
+}
+
+func TestS3Storage_RequiresCredentials(t *testing.T) {
+	if _, err := NewS3Storage(S3Options{Endpoint: "http://localhost:9000", Bucket: "media"}); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
+
+func TestS3Storage_DoesNotLimitBodies(t *testing.T) {
+	store, err := NewS3Storage(S3Options{
+		Endpoint: 	"http://localhost:9000",
+		Bucket: 	"media",
+		AccessKey: 	"access",
+		SecretKey: 	"secret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create s3 storage: %v", err)
+	}
+
+	if store.client.Timeout != 0 {
+		t.Errorf("expected no client timeout, which would cut off streamed bodies, got %v", store.client.Timeout)
+	}
+
+	transport, ok := store.client.Transport.(*http.Transport)
+	if !ok || transport.ResponseHeaderTimeout == 0 || transport.TLSHandshakeTimeout == 0 {
+		t.Error("expected the transport to bound the wait for a response")
+	}
+}
\ No newline at end of file
@@ -1,21 +1,46 @@
 package router
 
 import (
-	"backend/src/router"
+	"backend/src/app"
+	"backend/src/config"
+	"backend/src/storage"
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 )
 
+// ============ Implementation of Mocks and Stubs =============
+
+// newTestRouter wires the whole application on a database that refuses
+// connections, so every route resolves but repositories fail fast.
+func newTestRouter(t *testing.T) http.Handler {
+	db, err := sql.Open("postgres", "host=127.0.0.1 port=1 sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+
+	cfg := config.Default()
+	return app.New(&cfg, db, store, 0).Handler
+}
+
+// ============ Test Cases =============
+
 func TestGenerate_NotNil(t *testing.T) {
-	r := router.Generate()
+	r := newTestRouter(t)
 	if r == nil {
 		t.Fatal("expected router to be not nil")
 	}
 }
 
 func TestGenerate_RoutesExist(t *testing.T) {
-	r := router.Generate()
+	r := newTestRouter(t)
 
 	tests := []struct {
 		method string
@@ -26,6 +51,29 @@ func TestGenerate_RoutesExist(t *testing.T) {
 		{"GET", "/users/1"},
 		{"PUT", "/users/1"},
 		{"DELETE", "/users/1"},
+		{"GET", "/users/1/profile"},
+		{"PUT", "/users/1/avatar"},
+		{"PUT", "/users/1/banner"},
+		{"GET", "/notifications"},
+		{"POST", "/notifications/read"},
+		{"GET", "/notifications/preferences"},
+		{"PUT", "/notifications/preferences"},
+		{"GET", "/search"},
+		{"POST", "/media"},
+		{"POST", "/lists"},
+		{"GET", "/lists"},
+		{"GET", "/lists/1"},
+		{"PUT", "/lists/1"},
+		{"DELETE", "/lists/1"},
+		{"GET", "/lists/1/members"},
+		{"PUT", "/lists/1/members/2"},
+		{"DELETE", "/lists/1/members/2"},
+		{"POST", "/users/1/export"},
+		{"GET", "/users/1/export"},
+		{"GET", "/exports/1"},
+		{"GET", "/health/live"},
+		{"GET", "/health/ready"},
+		{"GET", "/metrics"},
 	}
 
 	for _, paths := range tests {
@@ -40,7 +88,7 @@ func TestGenerate_RoutesExist(t *testing.T) {
 }
 
 func TestGenerate_MethodNotAllowed(t *testing.T) {
-	r := router.Generate()
+	r := newTestRouter(t)
 
 	req := httptest.NewRequest(http.MethodPatch, "/users/1", nil)
 	rr := httptest.NewRecorder()